@@ -1,30 +1,80 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
+	"determinizer/pkg/binfmt"
 	"determinizer/pkg/determinizer"
+	"determinizer/pkg/grammar"
 	"determinizer/pkg/model"
 	"determinizer/pkg/parser"
+	"determinizer/pkg/transform"
 	"determinizer/pkg/writer"
+	"dot/pkg/dot"
+	"fsm/pkg/automaton"
 )
 
 const (
 	nfaType     = "nfa"
 	grammarType = "grammar"
+
+	dfaEmit = "dfa"
+	nfaEmit = "nfa"
+
+	dotFormat          = "dot"
+	binaryFormat       = "bin"
+	rightGrammarFormat = "right-grammar"
+	leftGrammarFormat  = "left-grammar"
+
+	noMapping      = "none"
+	commentMapping = "comment"
+	csvMapping     = "csv"
+	jsonMapping    = "json"
+
+	markdownTrace = "markdown"
+	csvTrace      = "csv"
+	htmlTrace     = "html"
 )
 
+var grammarKindNames = map[model.GrammarKind]string{
+	model.RightLinear: "праволинейная",
+	model.LeftLinear:  "леволинейная",
+	model.MixedLinear: "смешанная",
+	model.NonRegular:  "нерегулярная",
+}
+
 type config struct {
-	input  *string
-	output *string
-	t      *string
+	input          *string
+	output         *string
+	t              *string
+	format         *string
+	merge          *bool
+	ranges         *bool
+	words          *bool
+	epsilonAliases *string
+	epsilon        *string
+	removeEpsilon  *bool
+	workers        *int
+	maxStates      *int
+	maxTime        *time.Duration
+	naming         *string
+	mapping        *string
+	mappingOutput  *string
+	trace          *string
+	traceFormat    *string
+	complete       *bool
+	stripSinks     *bool
+	emit           *string
 }
 
 func main() {
 	c := parseCliFlags()
-	assertInput(c.input, c.output)
+	assertInput(c)
 
 	var originalNFA *model.NFA
 	var err error
@@ -34,15 +84,26 @@ func main() {
 		fmt.Printf("Ошибка чтения входного файла: %v\n", err)
 		os.Exit(1)
 	}
+	if binfmt.IsBinary(data) {
+		fmt.Println("Входной файл содержит ДКА в двоичном формате, детерминизация не требуется.")
+		dfa, err := binfmt.Decode(data)
+		if err != nil {
+			fmt.Printf("Ошибка чтения двоичного ДКА: %v\n", err)
+			os.Exit(1)
+		}
+		writeDFA(c, dfa, nil)
+		return
+	}
 	inputString := string(data)
 
 	switch *c.t {
 	case nfaType:
-		originalNFA, err = parser.ParseNFA(inputString)
+		options := parser.Options{EpsilonAliases: strings.Split(*c.epsilonAliases, ",")}
+		originalNFA, err = parser.ParseNFAWithOptions(inputString, options)
 		fmt.Println("Парсинг входного файла как NFA (.dot)...")
 	case grammarType:
-		originalNFA, err = parser.ParseGrammarToNFA(inputString)
 		fmt.Println("Парсинг входного файла как грамматики...")
+		originalNFA, err = parseGrammar(inputString)
 	default:
 		fmt.Printf("Неизвестный тип входных данных: %s. Используйте 'nfa' или 'grammar'.\n", *c.t)
 		os.Exit(1)
@@ -54,11 +115,160 @@ func main() {
 	}
 	fmt.Printf("Успешно построен НКА с %d состояниями.\n", len(originalNFA.States))
 
-	d := determinizer.NewDeterminizer(originalNFA)
-	newDFA := d.Run()
+	if *c.words {
+		originalNFA = transform.ExpandWords(originalNFA)
+		fmt.Printf("Метки-слова развёрнуты в цепочки, НКА содержит %d состояний.\n", len(originalNFA.States))
+	}
+	if *c.removeEpsilon {
+		originalNFA = transform.RemoveEpsilon(originalNFA)
+		fmt.Println("ε-переходы удалены из НКА.")
+	}
+
+	if *c.emit == nfaEmit {
+		writeNFA(c, originalNFA)
+		return
+	}
+
+	naming, _ := determinizer.ParseNamingStrategy(*c.naming)
+	d := determinizer.NewDeterminizerWithOptions(originalNFA, determinizer.Options{
+		Naming:   naming,
+		Trace:    *c.trace != "",
+		Complete: *c.complete,
+	})
+	limits := determinizer.Limits{MaxStates: *c.maxStates, MaxTime: *c.maxTime}
+	var newDFA *model.DFA
+	if *c.workers == 1 {
+		newDFA, err = d.RunContext(context.Background(), limits)
+	} else {
+		newDFA, err = d.RunParallelContext(context.Background(), *c.workers, limits)
+	}
+	if err != nil {
+		fmt.Printf("Ошибка детерминизации: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *c.trace != "" {
+		writeTrace(c, d.Trace())
+	}
+	writeDFA(c, newDFA, d.StateMapping())
+}
+
+func parseGrammar(input string) (*model.NFA, error) {
+	g, err := parser.ParseGrammar(input)
+	if err != nil {
+		return nil, err
+	}
+
+	analysis := grammar.Analyze(g)
+	fmt.Printf("Начальный символ: %s, тип грамматики: %s.\n", g.Start, grammarKindNames[analysis.Kind])
+	printNonTerminals("Неопределённые нетерминалы", analysis.Undefined)
+	printNonTerminals("Недостижимые нетерминалы", analysis.Unreachable)
+	printNonTerminals("Непродуктивные нетерминалы", analysis.Unproductive)
+	if len(analysis.Undefined)+len(analysis.Unreachable)+len(analysis.Unproductive) > 0 {
+		g = grammar.Normalize(g)
+		fmt.Printf("Бесполезные нетерминалы удалены, тип грамматики после нормализации: %s.\n", grammarKindNames[g.Kind])
+	}
+	return grammar.ToNFA(g)
+}
+
+func printNonTerminals(title string, nonTerminals []string) {
+	if len(nonTerminals) > 0 {
+		fmt.Printf("%s: %s\n", title, strings.Join(nonTerminals, ", "))
+	}
+}
+
+func writeNFA(c *config, nfa *model.NFA) {
+	if isGrammarFormat(*c.format) {
+		writeGrammar(c, nfa)
+		return
+	}
+	w := writer.NewWriterWithOptions(writer.Options{
+		MergeParallelEdges: *c.merge || *c.ranges,
+		CompressRanges:     *c.ranges,
+		Epsilon:            *c.epsilon,
+	})
+	if err := w.WriteNFAToFile(nfa, *c.output); err != nil {
+		fmt.Printf("Ошибка записи выходного файла: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Успешно записан НКА в %s\n", *c.output)
+}
+
+func writeGrammar(c *config, nfa *model.NFA) {
+	var grammar *model.Grammar
+	var err error
+	if *c.format == leftGrammarFormat {
+		grammar, err = transform.LeftLinearGrammar(nfa)
+	} else {
+		grammar, err = transform.RightLinearGrammar(nfa)
+	}
+	if err != nil {
+		fmt.Printf("Ошибка построения грамматики: %v\n", err)
+		os.Exit(1)
+	}
+	if err = writer.WriteGrammarToFile(grammar, *c.output); err != nil {
+		fmt.Printf("Ошибка записи выходного файла: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Успешно записана грамматика в %s\n", *c.output)
+}
+
+func isGrammarFormat(format string) bool {
+	return format == rightGrammarFormat || format == leftGrammarFormat
+}
+
+func writeTrace(c *config, trace []model.TraceStep) {
+	var err error
+	switch *c.traceFormat {
+	case markdownTrace:
+		err = writer.WriteTraceMarkdown(trace, *c.trace)
+	case csvTrace:
+		err = writer.WriteTraceCSV(trace, *c.trace)
+	case htmlTrace:
+		err = writer.WriteTraceHTML(trace, *c.trace)
+	}
+	if err != nil {
+		fmt.Printf("Ошибка записи трассировки: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Трассировка построения подмножеств записана в %s\n", *c.trace)
+}
 
-	w := writer.NewWriter()
-	err = w.WriteToFile(newDFA, *c.output)
+func writeDFA(c *config, dfa *model.DFA, mapping []model.StateMapping) {
+	var err error
+	switch *c.mapping {
+	case csvMapping:
+		err = writer.WriteMappingCSV(mapping, *c.mappingOutput)
+	case jsonMapping:
+		err = writer.WriteMappingJSON(mapping, *c.mappingOutput)
+	}
+	if err != nil {
+		fmt.Printf("Ошибка записи таблицы соответствия состояний: %v\n", err)
+		os.Exit(1)
+	}
+
+	var commentMappings []model.StateMapping
+	if *c.mapping == commentMapping {
+		commentMappings = mapping
+	}
+
+	if isGrammarFormat(*c.format) {
+		writeGrammar(c, transform.DFAToNFA(dfa))
+		return
+	}
+	if *c.format == binaryFormat {
+		err = binfmt.WriteToFile(automaton.ApplySinkOptions(dfa, *c.complete, *c.stripSinks), *c.output)
+	} else {
+		w := writer.NewWriterWithOptions(writer.Options{
+			MergeParallelEdges: *c.merge || *c.ranges,
+			CompressRanges:     *c.ranges,
+			Epsilon:            *c.epsilon,
+			StateMapping:       commentMappings,
+			CompleteWithSink:   *c.complete,
+			StripSinkStates:    *c.stripSinks,
+		})
+		err = w.WriteToFile(dfa, *c.output)
+	}
 	if err != nil {
 		fmt.Printf("Ошибка записи выходного файла: %v\n", err)
 		os.Exit(1)
@@ -66,9 +276,49 @@ func main() {
 	fmt.Printf("Успешно записан ДКА в %s\n", *c.output)
 }
 
-func assertInput(inputFile *string, outputFile *string) {
-	if *inputFile == "" || *outputFile == "" {
-		fmt.Println("Использование: go run . -in <input_file> -out <output_file> [-type <nfa|grammar>]")
+func assertInput(c *config) {
+	if *c.input == "" || *c.output == "" {
+		fmt.Println("Использование: go run . -in <input_file> -out <output_file> [-type <nfa|grammar>] [-format <dot|bin|right-grammar|left-grammar>] [-merge] [-ranges] [-words] [-eps-aliases <list>] [-eps <symbol>] [-remove-eps] [-workers <n>] [-max-states <n>] [-max-time <duration>] [-naming <original|sequential|set>] [-mapping <none|comment|csv|json>] [-mapping-out <file>] [-trace <file>] [-trace-format <markdown|csv|html>] [-complete | -strip-sinks] [-emit <dfa|nfa>]")
+		os.Exit(1)
+	}
+	if _, err := determinizer.ParseNamingStrategy(*c.naming); err != nil {
+		fmt.Printf("Неизвестная схема именования состояний: %s. Используйте 'original', 'sequential' или 'set'.\n", *c.naming)
+		os.Exit(1)
+	}
+	switch *c.mapping {
+	case noMapping, commentMapping:
+	case csvMapping, jsonMapping:
+		if *c.mappingOutput == "" {
+			fmt.Println("Для таблицы соответствия в формате csv или json укажите файл флагом -mapping-out.")
+			os.Exit(1)
+		}
+	default:
+		fmt.Printf("Неизвестный формат таблицы соответствия: %s. Используйте 'none', 'comment', 'csv' или 'json'.\n", *c.mapping)
+		os.Exit(1)
+	}
+	switch *c.emit {
+	case dfaEmit:
+	case nfaEmit:
+		if *c.format == binaryFormat {
+			fmt.Println("Двоичный формат поддерживается только для ДКА.")
+			os.Exit(1)
+		}
+	default:
+		fmt.Printf("Неизвестный тип результата: %s. Используйте 'dfa' или 'nfa'.\n", *c.emit)
+		os.Exit(1)
+	}
+	if *c.complete && *c.stripSinks {
+		fmt.Println("Флаги -complete и -strip-sinks нельзя использовать одновременно.")
+		os.Exit(1)
+	}
+	switch *c.traceFormat {
+	case markdownTrace, csvTrace, htmlTrace:
+	default:
+		fmt.Printf("Неизвестный формат трассировки: %s. Используйте 'markdown', 'csv' или 'html'.\n", *c.traceFormat)
+		os.Exit(1)
+	}
+	if *c.format != dotFormat && *c.format != binaryFormat && !isGrammarFormat(*c.format) {
+		fmt.Printf("Неизвестный формат вывода: %s. Используйте 'dot', 'bin', 'right-grammar' или 'left-grammar'.\n", *c.format)
 		os.Exit(1)
 	}
 }
@@ -77,11 +327,47 @@ func parseCliFlags() *config {
 	inputFile := flag.String("in", "", "Входной файл")
 	outputFile := flag.String("out", "", "Выходной файл")
 	inputType := flag.String("type", "nfa", "Тип входных данных: 'nfa' (файл .dot) или 'grammar' (файл с грамматикой)")
+	format := flag.String("format", dotFormat, "Формат выходного файла: 'dot', 'bin' (компактный двоичный формат), 'right-grammar' или 'left-grammar' (праволинейная или леволинейная грамматика)")
+	merge := flag.Bool("merge", false, "Объединять параллельные рёбра в одно с метками через запятую")
+	ranges := flag.Bool("ranges", false, "Объединять параллельные рёбра и сжимать подряд идущие символы в диапазоны вида a-z")
+	words := flag.Bool("words", false, "Разворачивать метки-слова (label=\"abc\") в цепочки переходов по одному символу")
+	epsilonAliases := flag.String("eps-aliases", strings.Join(dot.DefaultEpsilonAliases, ","), "Метки, обозначающие ε-переход, через запятую (пустой элемент означает пустую метку)")
+	epsilon := flag.String("eps", dot.EpsilonWord, "Обозначение ε-перехода в выходном файле")
+	removeEpsilon := flag.Bool("remove-eps", false, "Удалить ε-переходы из НКА перед детерминизацией")
+	workers := flag.Int("workers", 1, "Число потоков для построения подмножеств (0 — по числу ядер, 1 — последовательно)")
+	maxStates := flag.Int("max-states", 0, "Максимальное число состояний ДКА (0 — без ограничения)")
+	maxTime := flag.Duration("max-time", 0, "Максимальное время детерминизации, например 30s (0 — без ограничения)")
+	naming := flag.String("naming", string(determinizer.NamingOriginal), "Именование состояний ДКА: 'original' (q0_q1), 'sequential' (D0, D1, ...) или 'set' ({q0,q1})")
+	mapping := flag.String("mapping", noMapping, "Таблица соответствия состояний ДКА подмножествам НКА: 'none', 'comment' (комментарий в .dot), 'csv' или 'json'")
+	mappingOutput := flag.String("mapping-out", "", "Файл для таблицы соответствия в формате csv или json")
+	trace := flag.String("trace", "", "Файл для пошаговой трассировки построения подмножеств")
+	traceFormat := flag.String("trace-format", markdownTrace, "Формат трассировки: 'markdown', 'csv' или 'html'")
+	complete := flag.Bool("complete", false, "Достроить ДКА до полного, добавив тупиковое состояние (пустое подмножество)")
+	stripSinks := flag.Bool("strip-sinks", false, "Удалить тупиковые состояния, из которых недостижимы принимающие")
+	emit := flag.String("emit", dfaEmit, "Что записать в выходной файл: 'dfa' (результат детерминизации) или 'nfa' (промежуточный НКА)")
 	flag.Parse()
 
 	return &config{
-		input:  inputFile,
-		output: outputFile,
-		t:      inputType,
+		input:          inputFile,
+		output:         outputFile,
+		t:              inputType,
+		format:         format,
+		merge:          merge,
+		ranges:         ranges,
+		words:          words,
+		epsilonAliases: epsilonAliases,
+		epsilon:        epsilon,
+		removeEpsilon:  removeEpsilon,
+		workers:        workers,
+		maxStates:      maxStates,
+		maxTime:        maxTime,
+		naming:         naming,
+		mapping:        mapping,
+		mappingOutput:  mappingOutput,
+		trace:          trace,
+		traceFormat:    traceFormat,
+		complete:       complete,
+		stripSinks:     stripSinks,
+		emit:           emit,
 	}
 }
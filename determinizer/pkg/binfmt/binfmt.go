@@ -0,0 +1,153 @@
+package binfmt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"math"
+	"os"
+
+	"determinizer/pkg/model"
+)
+
+const (
+	Version = 1
+
+	headerSize   = 20
+	checksumSize = 4
+	noTransition = math.MaxUint32
+)
+
+var magic = [4]byte{'F', 'S', 'M', 'B'}
+
+type header struct {
+	version    uint16
+	flags      uint16
+	numStates  uint32
+	numSymbols uint32
+	startState uint32
+}
+
+func Encode(dfa *model.DFA) ([]byte, error) {
+	indices := make(map[string]uint32, len(dfa.States))
+	for i, state := range dfa.States {
+		indices[state] = uint32(i)
+	}
+	start, ok := indices[dfa.StartState]
+	if !ok {
+		return nil, fmt.Errorf("start state %q is not among DFA states", dfa.StartState)
+	}
+
+	var buf bytes.Buffer
+	buf.Write(magic[:])
+	writeUint16(&buf, Version)
+	writeUint16(&buf, 0)
+	writeUint32(&buf, uint32(len(dfa.States)))
+	writeUint32(&buf, uint32(len(dfa.Alphabet)))
+	writeUint32(&buf, start)
+
+	for _, state := range dfa.States {
+		for _, symbol := range dfa.Alphabet {
+			to, ok := dfa.Transitions[state][symbol]
+			if !ok {
+				writeUint32(&buf, noTransition)
+				continue
+			}
+			toIndex, ok := indices[to]
+			if !ok {
+				return nil, fmt.Errorf("transition from %q leads to unknown state %q", state, to)
+			}
+			writeUint32(&buf, toIndex)
+		}
+	}
+
+	accepting := make([]byte, bitsetSize(len(dfa.States)))
+	for i, state := range dfa.States {
+		if dfa.AcceptingStates[state] {
+			accepting[i/8] |= 1 << (i % 8)
+		}
+	}
+	buf.Write(accepting)
+
+	for _, symbol := range dfa.Alphabet {
+		writeString(&buf, symbol)
+	}
+	for _, state := range dfa.States {
+		writeString(&buf, state)
+	}
+
+	writeUint32(&buf, crc32.ChecksumIEEE(buf.Bytes()))
+	return buf.Bytes(), nil
+}
+
+func Decode(data []byte) (*model.DFA, error) {
+	f, err := newFile(data)
+	if err != nil {
+		return nil, err
+	}
+	return f.DFA()
+}
+
+func WriteToFile(dfa *model.DFA, filePath string) error {
+	data, err := Encode(dfa)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, data, 0644)
+}
+
+func Load(filePath string) (*model.DFA, error) {
+	f, err := Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return f.DFA()
+}
+
+func IsBinary(data []byte) bool {
+	return len(data) >= len(magic) && bytes.Equal(data[:len(magic)], magic[:])
+}
+
+func readHeader(data []byte) (header, error) {
+	if len(data) < headerSize+checksumSize {
+		return header{}, errors.New("binary DFA is truncated")
+	}
+	if !IsBinary(data) {
+		return header{}, errors.New("not a binary DFA file")
+	}
+
+	h := header{
+		version:    binary.LittleEndian.Uint16(data[4:]),
+		flags:      binary.LittleEndian.Uint16(data[6:]),
+		numStates:  binary.LittleEndian.Uint32(data[8:]),
+		numSymbols: binary.LittleEndian.Uint32(data[12:]),
+		startState: binary.LittleEndian.Uint32(data[16:]),
+	}
+	if h.version != Version {
+		return header{}, fmt.Errorf("unsupported binary DFA version %d", h.version)
+	}
+	if h.numStates == 0 || h.startState >= h.numStates {
+		return header{}, fmt.Errorf("invalid start state %d for %d states", h.startState, h.numStates)
+	}
+	return h, nil
+}
+
+func bitsetSize(n int) int {
+	return (n + 7) / 8
+}
+
+func writeUint16(buf *bytes.Buffer, v uint16) {
+	buf.Write(binary.LittleEndian.AppendUint16(nil, v))
+}
+
+func writeUint32(buf *bytes.Buffer, v uint32) {
+	buf.Write(binary.LittleEndian.AppendUint32(nil, v))
+}
+
+func writeString(buf *bytes.Buffer, s string) {
+	buf.Write(binary.AppendUvarint(nil, uint64(len(s))))
+	buf.WriteString(s)
+}
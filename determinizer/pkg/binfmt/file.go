@@ -0,0 +1,163 @@
+package binfmt
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+
+	"determinizer/pkg/model"
+)
+
+type File struct {
+	data        []byte
+	header      header
+	transitions []byte
+	accepting   []byte
+	symbols     []string
+	names       []string
+	release     func() error
+}
+
+func Open(filePath string) (*File, error) {
+	data, release, err := mapFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := newFile(data)
+	if err != nil {
+		_ = release()
+		return nil, fmt.Errorf("%s: %w", filePath, err)
+	}
+	f.release = release
+	return f, nil
+}
+
+func newFile(data []byte) (*File, error) {
+	h, err := readHeader(data)
+	if err != nil {
+		return nil, err
+	}
+
+	body := data[:len(data)-checksumSize]
+	expected := binary.LittleEndian.Uint32(data[len(data)-checksumSize:])
+	if crc32.ChecksumIEEE(body) != expected {
+		return nil, errors.New("binary DFA checksum mismatch")
+	}
+
+	transitionsSize := uint64(h.numStates) * uint64(h.numSymbols) * 4
+	acceptingSize := uint64(bitsetSize(int(h.numStates)))
+	if uint64(len(body)) < headerSize+transitionsSize+acceptingSize {
+		return nil, errors.New("binary DFA is truncated")
+	}
+
+	f := &File{
+		data:   data,
+		header: h,
+	}
+	offset := uint64(headerSize)
+	f.transitions = body[offset : offset+transitionsSize]
+	offset += transitionsSize
+	f.accepting = body[offset : offset+acceptingSize]
+	offset += acceptingSize
+	if err = f.checkTransitions(); err != nil {
+		return nil, err
+	}
+
+	rest := body[offset:]
+	if f.symbols, rest, err = readStrings(rest, int(h.numSymbols)); err != nil {
+		return nil, fmt.Errorf("failed to read alphabet: %w", err)
+	}
+	if f.names, rest, err = readStrings(rest, int(h.numStates)); err != nil {
+		return nil, fmt.Errorf("failed to read state names: %w", err)
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("unexpected trailing data in binary DFA")
+	}
+	return f, nil
+}
+
+func (f *File) Close() error {
+	if f.release == nil {
+		return nil
+	}
+	release := f.release
+	f.release = nil
+	return release()
+}
+
+func (f *File) NumStates() int {
+	return int(f.header.numStates)
+}
+
+func (f *File) Alphabet() []string {
+	return f.symbols
+}
+
+func (f *File) StartState() uint32 {
+	return f.header.startState
+}
+
+func (f *File) StateName(state uint32) string {
+	return f.names[state]
+}
+
+func (f *File) Next(state uint32, symbolIndex int) (uint32, bool) {
+	offset := (uint64(state)*uint64(f.header.numSymbols) + uint64(symbolIndex)) * 4
+	to := binary.LittleEndian.Uint32(f.transitions[offset:])
+	return to, to != noTransition
+}
+
+func (f *File) IsAccepting(state uint32) bool {
+	return f.accepting[state/8]&(1<<(state%8)) != 0
+}
+
+func (f *File) DFA() (*model.DFA, error) {
+	dfa := model.NewDFA()
+	dfa.Alphabet = append(dfa.Alphabet, f.symbols...)
+	dfa.States = append(dfa.States, f.names...)
+	dfa.StartState = f.names[f.header.startState]
+
+	for state := uint32(0); state < f.header.numStates; state++ {
+		name := f.names[state]
+		if f.IsAccepting(state) {
+			dfa.AcceptingStates[name] = true
+		}
+		for symbolIndex, symbol := range f.symbols {
+			to, ok := f.Next(state, symbolIndex)
+			if !ok {
+				continue
+			}
+			if _, exists := dfa.Transitions[name]; !exists {
+				dfa.Transitions[name] = make(map[string]string)
+			}
+			dfa.Transitions[name][symbol] = f.names[to]
+		}
+	}
+	return dfa, nil
+}
+
+func (f *File) checkTransitions() error {
+	for offset := 0; offset < len(f.transitions); offset += 4 {
+		to := binary.LittleEndian.Uint32(f.transitions[offset:])
+		if to != noTransition && to >= f.header.numStates {
+			state := uint64(offset/4) / uint64(f.header.numSymbols)
+			return fmt.Errorf("transition from state %d leads to invalid state %d", state, to)
+		}
+	}
+	return nil
+}
+
+func readStrings(data []byte, count int) ([]string, []byte, error) {
+	result := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		length, n := binary.Uvarint(data)
+		if n <= 0 || uint64(len(data)-n) < length {
+			return nil, nil, errors.New("string table is truncated")
+		}
+		result = append(result, string(data[n:n+int(length)]))
+		data = data[n+int(length):]
+	}
+	return result, data, nil
+}
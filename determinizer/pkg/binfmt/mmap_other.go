@@ -0,0 +1,13 @@
+//go:build !unix
+
+package binfmt
+
+import "os"
+
+func mapFile(filePath string) ([]byte, func() error, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, func() error { return nil }, nil
+}
@@ -0,0 +1,84 @@
+package bitset
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+const wordSize = 64
+
+type Set []uint64
+
+func New(size int) Set {
+	return make(Set, (size+wordSize-1)/wordSize)
+}
+
+func (s Set) Add(i int) {
+	s[i/wordSize] |= 1 << (uint(i) % wordSize)
+}
+
+func (s Set) Has(i int) bool {
+	return s[i/wordSize]&(1<<(uint(i)%wordSize)) != 0
+}
+
+func (s Set) Union(other Set) {
+	for i, word := range other {
+		s[i] |= word
+	}
+}
+
+func (s Set) Intersects(other Set) bool {
+	for i, word := range other {
+		if s[i]&word != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (s Set) Clear() {
+	for i := range s {
+		s[i] = 0
+	}
+}
+
+func (s Set) IsEmpty() bool {
+	for _, word := range s {
+		if word != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (s Set) Count() int {
+	count := 0
+	for _, word := range s {
+		count += bits.OnesCount64(word)
+	}
+	return count
+}
+
+func (s Set) Clone() Set {
+	clone := make(Set, len(s))
+	copy(clone, s)
+	return clone
+}
+
+func (s Set) ForEach(fn func(i int)) {
+	for wordIndex, word := range s {
+		for word != 0 {
+			bit := bits.TrailingZeros64(word)
+			fn(wordIndex*wordSize + bit)
+			word &= word - 1
+		}
+	}
+}
+
+func (s Set) Key() string {
+	buf := make([]byte, 0, len(s)*8)
+	for _, word := range s {
+		buf = binary.LittleEndian.AppendUint64(buf, word)
+	}
+	return string(buf)
+}
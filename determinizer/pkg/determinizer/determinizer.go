@@ -1,27 +1,36 @@
 package determinizer
 
 import (
+	"context"
 	"sort"
 	"strings"
 
+	"determinizer/pkg/bitset"
 	"determinizer/pkg/model"
 )
 
-const EpsilonSymbol = "eps"
+const EpsilonSymbol = model.Epsilon
 
 type Determinizer struct {
-	nfa        *model.NFA
+	options    Options
+	nfa        *indexedNFA
 	dfa        *model.DFA
-	dStates    [][]string
-	dStatesMap map[string]int
+	subsets    []bitset.Set
+	subsetKeys map[string]int
+	table      [][]int
 	queue      []int
+	trace      []traceStep
 }
 
 func NewDeterminizer(nfa *model.NFA) *Determinizer {
+	return NewDeterminizerWithOptions(nfa, Options{Naming: NamingOriginal})
+}
+
+func NewDeterminizerWithOptions(nfa *model.NFA, options Options) *Determinizer {
 	d := &Determinizer{
-		nfa:        nfa,
+		options:    options,
 		dfa:        model.NewDFA(),
-		dStatesMap: make(map[string]int),
+		subsetKeys: make(map[string]int),
 	}
 
 	for _, symbol := range nfa.Alphabet {
@@ -31,138 +40,113 @@ func NewDeterminizer(nfa *model.NFA) *Determinizer {
 	}
 	sort.Strings(d.dfa.Alphabet)
 
+	d.nfa = indexNFA(nfa, d.dfa.Alphabet)
 	d.initializeStartState()
 	return d
 }
 
 func (d *Determinizer) Run() *model.DFA {
-	for len(d.queue) > 0 {
+	dfa, _ := d.RunContext(context.Background(), Limits{})
+	return dfa
+}
+
+func (d *Determinizer) RunContext(ctx context.Context, limits Limits) (*model.DFA, error) {
+	l, cancel := newLimiter(ctx, limits)
+	defer cancel()
+
+	moveResult := bitset.New(len(d.nfa.names))
+	for processed := 0; len(d.queue) > 0; processed++ {
+		if err := l.Check(len(d.subsets), processed); err != nil {
+			return nil, err
+		}
 		currentIndex := d.queue[0]
 		d.queue = d.queue[1:]
-		d.processState(currentIndex)
+		d.processState(currentIndex, moveResult)
 	}
 	d.finalizeDFA()
 
-	return d.dfa
+	return d.dfa, nil
 }
 
 func (d *Determinizer) initializeStartState() {
-	startSet := epsilonClosure([]string{d.nfa.StartState}, d.nfa)
-	name, isNew := d.registerDFAState(startSet)
-
-	d.dfa.StartState = name
-	if isNew {
-		d.queue = append(d.queue, d.dStatesMap[name])
+	startSet := bitset.New(len(d.nfa.names))
+	for _, state := range d.nfa.startStates {
+		startSet.Union(d.nfa.closures[state])
+	}
+	d.registerSubset(startSet)
+	if d.options.Trace {
+		d.recordStart()
 	}
 }
 
-func (d *Determinizer) processState(stateIndex int) {
-	T := d.dStates[stateIndex]
-	fromStateName := makeStateName(T)
-
-	for _, symbol := range d.dfa.Alphabet {
-		moveResult := move(T, symbol, d.nfa)
-		if len(moveResult) == 0 {
+func (d *Determinizer) processState(subsetIndex int, moveResult bitset.Set) {
+	for column := range d.dfa.Alphabet {
+		if !d.move(d.subsets[subsetIndex], column, moveResult) && !d.options.Complete {
+			if d.options.Trace {
+				d.recordMove(subsetIndex, column, noTransition, false)
+			}
 			continue
 		}
 
-		U := epsilonClosure(moveResult, d.nfa)
-		toStateName, isNew := d.registerDFAState(U)
-
-		if isNew {
-			d.queue = append(d.queue, d.dStatesMap[toStateName])
+		to, exists := d.subsetKeys[moveResult.Key()]
+		if !exists {
+			to = d.registerSubset(moveResult.Clone())
+		}
+		d.table[subsetIndex][column] = to
+		if d.options.Trace {
+			d.recordMove(subsetIndex, column, to, !exists)
 		}
-		d.addTransition(fromStateName, symbol, toStateName)
 	}
 }
 
-func (d *Determinizer) registerDFAState(nfaStates []string) (string, bool) {
-	name := makeStateName(nfaStates)
-	if _, exists := d.dStatesMap[name]; exists {
-		return name, false
-	}
-
-	index := len(d.dStates)
-	d.dStatesMap[name] = index
-	d.dStates = append(d.dStates, nfaStates)
-	return name, true
+func (d *Determinizer) move(subset bitset.Set, column int, moveResult bitset.Set) bool {
+	moveResult.Clear()
+	subset.ForEach(func(state int) {
+		for _, dest := range d.nfa.transitions[state][column] {
+			moveResult.Union(d.nfa.closures[dest])
+		}
+	})
+	return !moveResult.IsEmpty()
 }
 
-func (d *Determinizer) addTransition(from, symbol, to string) {
-	if _, ok := d.dfa.Transitions[from]; !ok {
-		d.dfa.Transitions[from] = make(map[string]string)
-	}
-	d.dfa.Transitions[from][symbol] = to
-}
+func (d *Determinizer) registerSubset(subset bitset.Set) int {
+	index := len(d.subsets)
+	d.subsetKeys[subset.Key()] = index
+	d.subsets = append(d.subsets, subset)
+	d.queue = append(d.queue, index)
 
-func (d *Determinizer) finalizeDFA() {
-	for name, index := range d.dStatesMap {
-		d.dfa.States = append(d.dfa.States, name)
-		nfaStates := d.dStates[index]
-
-		for _, nfaState := range nfaStates {
-			if d.nfa.AcceptingStates[nfaState] {
-				d.dfa.AcceptingStates[name] = true
-				break
-			}
-		}
+	row := make([]int, len(d.dfa.Alphabet))
+	for column := range row {
+		row[column] = noTransition
 	}
-	sort.Strings(d.dfa.States)
+	d.table = append(d.table, row)
+	return index
 }
 
-func epsilonClosure(states []string, nfa *model.NFA) []string {
-	closureSet := make(map[string]bool)
-	stack := make([]string, 0, len(states))
-
-	for _, state := range states {
-		if !closureSet[state] {
-			closureSet[state] = true
-			stack = append(stack, state)
+func (d *Determinizer) finalizeDFA() {
+	names := make([]string, len(d.subsets))
+	for index, subset := range d.subsets {
+		names[index] = d.stateName(index)
+		d.dfa.States = append(d.dfa.States, names[index])
+		if subset.Intersects(d.nfa.accepting) {
+			d.dfa.AcceptingStates[names[index]] = true
 		}
 	}
+	d.dfa.StartState = names[0]
 
-	for len(stack) > 0 {
-		currentState := stack[len(stack)-1]
-		stack = stack[:len(stack)-1]
-
-		if transitions, ok := nfa.Transitions[currentState]; ok {
-			if dest, ok := transitions[EpsilonSymbol]; ok {
-				for _, destState := range dest {
-					if !closureSet[destState] {
-						closureSet[destState] = true
-						stack = append(stack, destState)
-					}
-				}
+	for index, row := range d.table {
+		for column, to := range row {
+			if to == noTransition {
+				continue
 			}
-		}
-	}
-
-	result := make([]string, 0, len(closureSet))
-	for state := range closureSet {
-		result = append(result, state)
-	}
-	sort.Strings(result)
-	return result
-}
-
-func move(states []string, symbol string, nfa *model.NFA) []string {
-	destinations := make(map[string]bool)
-	for _, state := range states {
-		if transitions, ok := nfa.Transitions[state]; ok {
-			if destStates, ok := transitions[symbol]; ok {
-				for _, dest := range destStates {
-					destinations[dest] = true
-				}
+			from := names[index]
+			if _, ok := d.dfa.Transitions[from]; !ok {
+				d.dfa.Transitions[from] = make(map[string]string)
 			}
+			d.dfa.Transitions[from][d.dfa.Alphabet[column]] = names[to]
 		}
 	}
-
-	result := make([]string, 0, len(destinations))
-	for state := range destinations {
-		result = append(result, state)
-	}
-	sort.Strings(result)
-	return result
+	sort.Strings(d.dfa.States)
 }
 
 func makeStateName(states []string) string {
@@ -0,0 +1,98 @@
+package determinizer
+
+import (
+	"sort"
+
+	"determinizer/pkg/bitset"
+	"determinizer/pkg/model"
+)
+
+const noTransition = -1
+
+type indexedNFA struct {
+	names       []string
+	startStates []int
+	accepting   bitset.Set
+	closures    []bitset.Set
+	transitions [][][]int
+}
+
+func indexNFA(nfa *model.NFA, alphabet []string) *indexedNFA {
+	stateSet := make(map[string]bool)
+	for _, state := range nfa.StartStates {
+		stateSet[state] = true
+	}
+	for _, state := range nfa.States {
+		stateSet[state] = true
+	}
+	for from, transitions := range nfa.Transitions {
+		stateSet[from] = true
+		for _, destStates := range transitions {
+			for _, dest := range destStates {
+				stateSet[dest] = true
+			}
+		}
+	}
+
+	n := &indexedNFA{}
+	for state := range stateSet {
+		n.names = append(n.names, state)
+	}
+	sort.Strings(n.names)
+
+	columns := make(map[string]int, len(alphabet))
+	for column, symbol := range alphabet {
+		columns[symbol] = column
+	}
+
+	epsilon := make([][]int, len(n.names))
+	n.accepting = bitset.New(len(n.names))
+	n.transitions = make([][][]int, len(n.names))
+	for index, state := range n.names {
+		if nfa.AcceptingStates[state] {
+			n.accepting.Add(index)
+		}
+		n.transitions[index] = make([][]int, len(alphabet))
+		for symbol, destStates := range nfa.Transitions[state] {
+			for _, dest := range destStates {
+				destIndex := n.index(dest)
+				if symbol == EpsilonSymbol {
+					epsilon[index] = append(epsilon[index], destIndex)
+				} else if column, ok := columns[symbol]; ok {
+					n.transitions[index][column] = append(n.transitions[index][column], destIndex)
+				}
+			}
+		}
+	}
+
+	for _, state := range nfa.StartStates {
+		n.startStates = append(n.startStates, n.index(state))
+	}
+
+	n.closures = make([]bitset.Set, len(n.names))
+	for index := range n.names {
+		n.closures[index] = closureOf(index, epsilon, len(n.names))
+	}
+	return n
+}
+
+func closureOf(state int, epsilon [][]int, size int) bitset.Set {
+	closureSet := bitset.New(size)
+	closureSet.Add(state)
+	stack := []int{state}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, dest := range epsilon[current] {
+			if !closureSet.Has(dest) {
+				closureSet.Add(dest)
+				stack = append(stack, dest)
+			}
+		}
+	}
+	return closureSet
+}
+
+func (n *indexedNFA) index(state string) int {
+	return sort.SearchStrings(n.names, state)
+}
@@ -0,0 +1,19 @@
+package determinizer
+
+import (
+	"context"
+
+	"fsm/pkg/limits"
+)
+
+var ErrStateLimit = limits.ErrStateLimit
+
+type (
+	Limits     = limits.Limits
+	Stats      = limits.Stats
+	LimitError = limits.LimitError
+)
+
+func newLimiter(ctx context.Context, l Limits) (*limits.Limiter, context.CancelFunc) {
+	return limits.NewLimiter(ctx, l)
+}
@@ -0,0 +1,67 @@
+package determinizer
+
+import (
+	"fmt"
+	"strings"
+
+	"determinizer/pkg/bitset"
+	"determinizer/pkg/model"
+	"fsm/pkg/automaton"
+)
+
+type NamingStrategy string
+
+const (
+	NamingOriginal   NamingStrategy = "original"
+	NamingSequential NamingStrategy = "sequential"
+	NamingSet        NamingStrategy = "set"
+
+	sequentialStatePrefix = "D"
+)
+
+type Options struct {
+	Naming   NamingStrategy
+	Trace    bool
+	Complete bool
+}
+
+func ParseNamingStrategy(name string) (NamingStrategy, error) {
+	switch strategy := NamingStrategy(name); strategy {
+	case NamingOriginal, NamingSequential, NamingSet:
+		return strategy, nil
+	}
+	return "", fmt.Errorf("unknown naming strategy %q", name)
+}
+
+func (d *Determinizer) StateMapping() []model.StateMapping {
+	mapping := make([]model.StateMapping, len(d.subsets))
+	for index, subset := range d.subsets {
+		mapping[index] = model.StateMapping{
+			State:  d.stateName(index),
+			Subset: d.nfa.subsetMembers(subset),
+		}
+	}
+	return mapping
+}
+
+func (d *Determinizer) stateName(index int) string {
+	switch d.options.Naming {
+	case NamingSequential:
+		return fmt.Sprintf("%s%d", sequentialStatePrefix, index)
+	case NamingSet:
+		return "{" + strings.Join(d.nfa.subsetMembers(d.subsets[index]), ",") + "}"
+	default:
+		if d.subsets[index].IsEmpty() {
+			return automaton.SinkStateName(d.nfa.names)
+		}
+		return makeStateName(d.nfa.subsetMembers(d.subsets[index]))
+	}
+}
+
+func (n *indexedNFA) subsetMembers(subset bitset.Set) []string {
+	states := make([]string, 0, subset.Count())
+	subset.ForEach(func(state int) {
+		states = append(states, n.names[state])
+	})
+	return states
+}
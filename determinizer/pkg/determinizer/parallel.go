@@ -0,0 +1,98 @@
+package determinizer
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"determinizer/pkg/bitset"
+	"determinizer/pkg/model"
+)
+
+type pendingMove struct {
+	subset bitset.Set
+	key    string
+}
+
+func (d *Determinizer) RunParallel(workers int) *model.DFA {
+	dfa, _ := d.RunParallelContext(context.Background(), workers, Limits{})
+	return dfa
+}
+
+func (d *Determinizer) RunParallelContext(ctx context.Context, workers int, limits Limits) (*model.DFA, error) {
+	if d.options.Trace {
+		return d.RunContext(ctx, limits)
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	l, cancel := newLimiter(ctx, limits)
+	defer cancel()
+
+	processed := 0
+	for len(d.queue) > 0 {
+		if err := l.Check(len(d.subsets), processed); err != nil {
+			return nil, err
+		}
+		frontier := d.queue
+		d.queue = nil
+
+		moves := d.computeFrontier(l.Context(), frontier, workers)
+		if err := l.Check(len(d.subsets), processed); err != nil {
+			return nil, err
+		}
+		processed += len(frontier)
+		for i, subsetIndex := range frontier {
+			for column, pending := range moves[i] {
+				if pending.subset == nil {
+					continue
+				}
+				to, exists := d.subsetKeys[pending.key]
+				if !exists {
+					to = d.registerSubset(pending.subset)
+					if err := l.Check(len(d.subsets), processed); err != nil {
+						return nil, err
+					}
+				}
+				d.table[subsetIndex][column] = to
+			}
+		}
+	}
+	d.finalizeDFA()
+
+	return d.dfa, nil
+}
+
+func (d *Determinizer) computeFrontier(ctx context.Context, frontier []int, workers int) [][]pendingMove {
+	moves := make([][]pendingMove, len(frontier))
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < min(workers, len(frontier)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			moveResult := bitset.New(len(d.nfa.names))
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= len(frontier) || ctx.Err() != nil {
+					return
+				}
+				moves[i] = d.computeMoves(d.subsets[frontier[i]], moveResult)
+			}
+		}()
+	}
+	wg.Wait()
+	return moves
+}
+
+func (d *Determinizer) computeMoves(subset bitset.Set, moveResult bitset.Set) []pendingMove {
+	result := make([]pendingMove, len(d.dfa.Alphabet))
+	for column := range d.dfa.Alphabet {
+		if !d.move(subset, column, moveResult) && !d.options.Complete {
+			continue
+		}
+		result[column] = pendingMove{subset: moveResult.Clone(), key: moveResult.Key()}
+	}
+	return result
+}
@@ -0,0 +1,55 @@
+package determinizer
+
+import (
+	"determinizer/pkg/bitset"
+	"determinizer/pkg/model"
+)
+
+const initialStep = -1
+
+type traceStep struct {
+	from   int
+	column int
+	move   bitset.Set
+	to     int
+	isNew  bool
+}
+
+func (d *Determinizer) Trace() []model.TraceStep {
+	steps := make([]model.TraceStep, 0, len(d.trace))
+	for _, step := range d.trace {
+		result := model.TraceStep{
+			Move:  d.nfa.subsetMembers(step.move),
+			IsNew: step.isNew,
+		}
+		if step.from != initialStep {
+			result.From = d.stateName(step.from)
+			result.FromSubset = d.nfa.subsetMembers(d.subsets[step.from])
+			result.Symbol = d.dfa.Alphabet[step.column]
+		}
+		if step.to != noTransition {
+			result.To = d.stateName(step.to)
+			result.Closure = d.nfa.subsetMembers(d.subsets[step.to])
+		}
+		steps = append(steps, result)
+	}
+	return steps
+}
+
+func (d *Determinizer) recordStart() {
+	move := bitset.New(len(d.nfa.names))
+	for _, state := range d.nfa.startStates {
+		move.Add(state)
+	}
+	d.trace = append(d.trace, traceStep{from: initialStep, move: move, to: 0, isNew: true})
+}
+
+func (d *Determinizer) recordMove(from, column, to int, isNew bool) {
+	move := bitset.New(len(d.nfa.names))
+	d.subsets[from].ForEach(func(state int) {
+		for _, dest := range d.nfa.transitions[state][column] {
+			move.Add(dest)
+		}
+	})
+	d.trace = append(d.trace, traceStep{from: from, column: column, move: move, to: to, isNew: isNew})
+}
@@ -0,0 +1,216 @@
+package grammar
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"determinizer/pkg/model"
+	"dot/pkg/dot"
+)
+
+type productionForm int
+
+const (
+	neutralForm productionForm = iota
+	rightForm
+	leftForm
+	nonRegularForm
+)
+
+type Rule struct {
+	NonTerminal string
+	Production  model.Production
+}
+
+type Analysis struct {
+	Kind             model.GrammarKind
+	Unreachable      []string
+	Unproductive     []string
+	Undefined        []string
+	RightLinearRules []Rule
+	LeftLinearRules  []Rule
+	NonRegularRules  []Rule
+}
+
+func Analyze(g *model.Grammar) *Analysis {
+	a := &Analysis{}
+	for _, nonTerminal := range g.NonTerminals {
+		for _, production := range g.Productions[nonTerminal] {
+			rule := Rule{NonTerminal: nonTerminal, Production: production}
+			switch formOf(production) {
+			case rightForm:
+				a.RightLinearRules = append(a.RightLinearRules, rule)
+			case leftForm:
+				a.LeftLinearRules = append(a.LeftLinearRules, rule)
+			case nonRegularForm:
+				a.NonRegularRules = append(a.NonRegularRules, rule)
+			}
+		}
+	}
+
+	switch {
+	case len(a.NonRegularRules) > 0:
+		a.Kind = model.NonRegular
+	case len(a.RightLinearRules) > 0 && len(a.LeftLinearRules) > 0:
+		a.Kind = model.MixedLinear
+	case len(a.LeftLinearRules) > 0:
+		a.Kind = model.LeftLinear
+	default:
+		a.Kind = model.RightLinear
+	}
+
+	a.Undefined = undefinedNonTerminals(g)
+	a.Unreachable = unreachableNonTerminals(g)
+	a.Unproductive = unproductiveNonTerminals(g)
+	return a
+}
+
+func (a *Analysis) Err() error {
+	switch a.Kind {
+	case model.NonRegular:
+		rule := a.NonRegularRules[0]
+		return fmt.Errorf("грамматика не является регулярной: правило %s %s", FormatRule(rule), nonRegularReason(rule.Production))
+	case model.MixedLinear:
+		return fmt.Errorf("смешанная грамматика: правило %s праволинейное, а правило %s леволинейное",
+			FormatRule(a.RightLinearRules[0]), FormatRule(a.LeftLinearRules[0]))
+	}
+	return nil
+}
+
+func FormatRule(rule Rule) string {
+	if len(rule.Production.Symbols) == 0 {
+		return fmt.Sprintf("<%s> -> %s", rule.NonTerminal, dot.EpsilonWord)
+	}
+	parts := make([]string, len(rule.Production.Symbols))
+	for i, symbol := range rule.Production.Symbols {
+		if symbol.NonTerminal {
+			parts[i] = "<" + symbol.Value + ">"
+		} else {
+			parts[i] = strconv.Quote(symbol.Value)
+		}
+	}
+	return fmt.Sprintf("<%s> -> %s", rule.NonTerminal, strings.Join(parts, " "))
+}
+
+func formOf(production model.Production) productionForm {
+	symbols := production.Symbols
+	count := countNonTerminals(production)
+	switch {
+	case count == 0 || len(symbols) == 1:
+		return neutralForm
+	case count > 1:
+		return nonRegularForm
+	case symbols[len(symbols)-1].NonTerminal:
+		return rightForm
+	case symbols[0].NonTerminal:
+		return leftForm
+	}
+	return nonRegularForm
+}
+
+func nonRegularReason(production model.Production) string {
+	if countNonTerminals(production) > 1 {
+		return "содержит больше одного нетерминала"
+	}
+	return "содержит нетерминал в середине"
+}
+
+func countNonTerminals(production model.Production) int {
+	count := 0
+	for _, symbol := range production.Symbols {
+		if symbol.NonTerminal {
+			count++
+		}
+	}
+	return count
+}
+
+func undefinedNonTerminals(g *model.Grammar) []string {
+	defined := make(map[string]bool)
+	for _, nonTerminal := range g.NonTerminals {
+		defined[nonTerminal] = true
+	}
+	undefined := make(map[string]bool)
+	if !defined[g.Start] {
+		undefined[g.Start] = true
+	}
+	for _, nonTerminal := range g.NonTerminals {
+		for _, production := range g.Productions[nonTerminal] {
+			for _, symbol := range production.Symbols {
+				if symbol.NonTerminal && !defined[symbol.Value] {
+					undefined[symbol.Value] = true
+				}
+			}
+		}
+	}
+
+	result := make([]string, 0, len(undefined))
+	for nonTerminal := range undefined {
+		result = append(result, nonTerminal)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func unreachableNonTerminals(g *model.Grammar) []string {
+	reachable := map[string]bool{g.Start: true}
+	stack := []string{g.Start}
+	for len(stack) > 0 {
+		nonTerminal := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, production := range g.Productions[nonTerminal] {
+			for _, symbol := range production.Symbols {
+				if symbol.NonTerminal && !reachable[symbol.Value] {
+					reachable[symbol.Value] = true
+					stack = append(stack, symbol.Value)
+				}
+			}
+		}
+	}
+
+	var result []string
+	for _, nonTerminal := range g.NonTerminals {
+		if !reachable[nonTerminal] {
+			result = append(result, nonTerminal)
+		}
+	}
+	return result
+}
+
+func unproductiveNonTerminals(g *model.Grammar) []string {
+	productive := make(map[string]bool)
+	for changed := true; changed; {
+		changed = false
+		for _, nonTerminal := range g.NonTerminals {
+			if productive[nonTerminal] {
+				continue
+			}
+			for _, production := range g.Productions[nonTerminal] {
+				if isProductive(production, productive) {
+					productive[nonTerminal] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	var result []string
+	for _, nonTerminal := range g.NonTerminals {
+		if !productive[nonTerminal] {
+			result = append(result, nonTerminal)
+		}
+	}
+	return result
+}
+
+func isProductive(production model.Production, productive map[string]bool) bool {
+	for _, symbol := range production.Symbols {
+		if symbol.NonTerminal && !productive[symbol.Value] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,22 @@
+package grammar
+
+import (
+	"determinizer/pkg/model"
+	"determinizer/pkg/transform"
+)
+
+func ToRightLinear(g *model.Grammar) (*model.Grammar, error) {
+	nfa, err := ToNFA(g)
+	if err != nil {
+		return nil, err
+	}
+	return transform.RightLinearGrammar(nfa)
+}
+
+func ToLeftLinear(g *model.Grammar) (*model.Grammar, error) {
+	nfa, err := ToNFA(g)
+	if err != nil {
+		return nil, err
+	}
+	return transform.LeftLinearGrammar(nfa)
+}
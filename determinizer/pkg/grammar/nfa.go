@@ -0,0 +1,157 @@
+package grammar
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"determinizer/pkg/model"
+)
+
+const (
+	finalStateName   = "H"
+	newStartState    = "F"
+	chainStatePrefix = "G"
+)
+
+type nfaBuilder struct {
+	grammar     *model.Grammar
+	nfa         *model.NFA
+	alphabetSet map[string]bool
+	taken       map[string]bool
+	chainCount  int
+}
+
+func ToNFA(g *model.Grammar) (*model.NFA, error) {
+	analysis := Analyze(g)
+	if err := analysis.Err(); err != nil {
+		return nil, err
+	}
+	if len(analysis.Undefined) > 0 {
+		return nil, fmt.Errorf("нетерминалы не определены: <%s>", strings.Join(analysis.Undefined, ">, <"))
+	}
+
+	b := &nfaBuilder{
+		grammar:     g,
+		nfa:         model.NewNFA(),
+		alphabetSet: make(map[string]bool),
+		taken:       make(map[string]bool),
+	}
+	for _, nonTerminal := range g.NonTerminals {
+		b.addState(nonTerminal)
+	}
+
+	if analysis.Kind == model.LeftLinear {
+		b.buildLeftLinear()
+	} else {
+		b.buildRightLinear()
+	}
+
+	for term := range b.alphabetSet {
+		b.nfa.Alphabet = append(b.nfa.Alphabet, term)
+	}
+	return b.nfa, nil
+}
+
+func (b *nfaBuilder) buildRightLinear() {
+	finalState := b.serviceState(finalStateName)
+	b.nfa.StartStates = []string{b.grammar.Start}
+	b.nfa.AcceptingStates[finalState] = true
+
+	for _, from := range b.grammar.NonTerminals {
+		for _, production := range b.grammar.Productions[from] {
+			if len(production.Symbols) == 0 {
+				b.nfa.AcceptingStates[from] = true
+				continue
+			}
+
+			terminals, nonTerminal := splitProduction(production)
+			to := nonTerminal
+			if to == "" {
+				to = finalState
+			}
+			b.addChain(from, terminals, to)
+		}
+	}
+}
+
+func (b *nfaBuilder) buildLeftLinear() {
+	newStart := b.serviceState(newStartState)
+	b.nfa.StartStates = []string{newStart}
+	b.nfa.AcceptingStates[b.grammar.Start] = true
+
+	for _, to := range b.grammar.NonTerminals {
+		for _, production := range b.grammar.Productions[to] {
+			terminals, nonTerminal := splitProduction(production)
+			from := nonTerminal
+			if from == "" {
+				from = newStart
+			}
+			b.addChain(from, terminals, to)
+		}
+	}
+}
+
+func splitProduction(production model.Production) ([]string, string) {
+	var terminals []string
+	nonTerminal := ""
+	for _, symbol := range production.Symbols {
+		if symbol.NonTerminal {
+			nonTerminal = symbol.Value
+		} else {
+			terminals = append(terminals, symbol.Value)
+		}
+	}
+	return terminals, nonTerminal
+}
+
+func (b *nfaBuilder) addChain(from string, terminals []string, to string) {
+	if len(terminals) == 0 {
+		b.addTransition(from, model.Epsilon, to)
+		return
+	}
+	current := from
+	for i, terminal := range terminals {
+		next := to
+		if i < len(terminals)-1 {
+			next = b.chainState()
+		}
+		b.addTransition(current, terminal, next)
+		current = next
+	}
+}
+
+func (b *nfaBuilder) addTransition(from, symbol, to string) {
+	if _, ok := b.nfa.Transitions[from]; !ok {
+		b.nfa.Transitions[from] = make(map[string][]string)
+	}
+	b.nfa.Transitions[from][symbol] = append(b.nfa.Transitions[from][symbol], to)
+	b.alphabetSet[symbol] = true
+}
+
+func (b *nfaBuilder) addState(name string) {
+	if !b.taken[name] {
+		b.taken[name] = true
+		b.nfa.States = append(b.nfa.States, name)
+	}
+}
+
+func (b *nfaBuilder) serviceState(base string) string {
+	name := base
+	for suffix := 1; b.taken[name]; suffix++ {
+		name = base + strconv.Itoa(suffix)
+	}
+	b.addState(name)
+	return name
+}
+
+func (b *nfaBuilder) chainState() string {
+	for {
+		b.chainCount++
+		name := chainStatePrefix + strconv.Itoa(b.chainCount)
+		if !b.taken[name] {
+			b.addState(name)
+			return name
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package grammar
+
+import "determinizer/pkg/model"
+
+func Normalize(g *model.Grammar) *model.Grammar {
+	unproductive := make(map[string]bool)
+	for _, nonTerminal := range unproductiveNonTerminals(g) {
+		unproductive[nonTerminal] = true
+	}
+	for _, nonTerminal := range undefinedNonTerminals(g) {
+		unproductive[nonTerminal] = true
+	}
+
+	productive := model.NewGrammar(g.Kind)
+	productive.Start = g.Start
+	for _, nonTerminal := range g.NonTerminals {
+		if unproductive[nonTerminal] && nonTerminal != g.Start {
+			continue
+		}
+		productive.NonTerminals = append(productive.NonTerminals, nonTerminal)
+		productive.Productions[nonTerminal] = nil
+		for _, production := range g.Productions[nonTerminal] {
+			if !usesAny(production, unproductive) {
+				productive.Productions[nonTerminal] = append(productive.Productions[nonTerminal], production)
+			}
+		}
+	}
+	if _, ok := productive.Productions[g.Start]; !ok {
+		productive.NonTerminals = append([]string{g.Start}, productive.NonTerminals...)
+		productive.Productions[g.Start] = nil
+	}
+
+	unreachable := make(map[string]bool)
+	for _, nonTerminal := range unreachableNonTerminals(productive) {
+		unreachable[nonTerminal] = true
+	}
+	result := model.NewGrammar(g.Kind)
+	result.Start = g.Start
+	for _, nonTerminal := range productive.NonTerminals {
+		if !unreachable[nonTerminal] {
+			result.NonTerminals = append(result.NonTerminals, nonTerminal)
+			result.Productions[nonTerminal] = productive.Productions[nonTerminal]
+		}
+	}
+	result.Kind = Analyze(result).Kind
+	return result
+}
+
+func usesAny(production model.Production, nonTerminals map[string]bool) bool {
+	for _, symbol := range production.Symbols {
+		if symbol.NonTerminal && nonTerminals[symbol.Value] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package model
+
+type GrammarKind string
+
+const (
+	RightLinear GrammarKind = "right"
+	LeftLinear  GrammarKind = "left"
+	MixedLinear GrammarKind = "mixed"
+	NonRegular  GrammarKind = "non-regular"
+)
+
+type GrammarSymbol struct {
+	Value       string
+	NonTerminal bool
+}
+
+type Production struct {
+	Symbols []GrammarSymbol
+}
+
+type Grammar struct {
+	Kind         GrammarKind
+	Start        string
+	NonTerminals []string
+	Productions  map[string][]Production
+}
+
+func NewGrammar(kind GrammarKind) *Grammar {
+	return &Grammar{
+		Kind:        kind,
+		Productions: make(map[string][]Production),
+	}
+}
+
+func Terminal(value string) GrammarSymbol {
+	return GrammarSymbol{Value: value}
+}
+
+func NonTerminal(name string) GrammarSymbol {
+	return GrammarSymbol{Value: name, NonTerminal: true}
+}
@@ -0,0 +1,6 @@
+package model
+
+type StateMapping struct {
+	State  string   `json:"state"`
+	Subset []string `json:"subset"`
+}
@@ -4,7 +4,7 @@ type NFA struct {
 	States          []string
 	Alphabet        []string
 	Transitions     map[string]map[string][]string
-	StartState      string
+	StartStates     []string
 	AcceptingStates map[string]bool
 }
 
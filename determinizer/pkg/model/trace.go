@@ -0,0 +1,11 @@
+package model
+
+type TraceStep struct {
+	From       string   `json:"from"`
+	FromSubset []string `json:"fromSubset"`
+	Symbol     string   `json:"symbol"`
+	Move       []string `json:"move"`
+	Closure    []string `json:"closure"`
+	To         string   `json:"to"`
+	IsNew      bool     `json:"isNew"`
+}
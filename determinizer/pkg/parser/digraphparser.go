@@ -1,32 +1,34 @@
 package parser
 
 import (
-	"bufio"
 	"errors"
 	"fmt"
-	"regexp"
 	"sort"
-	"strings"
 
 	"determinizer/pkg/model"
+	"dot/pkg/dot"
 )
 
-var (
-	acceptingStateRegex = regexp.MustCompile(`node\s*\[\s*shape\s*=\s*doublecircle\s*\];\s*([^;]+);`)
-	startStateRegex     = regexp.MustCompile(`start\s*->\s*(\w+);`)
-	transitionRegex     = regexp.MustCompile(`([\w_]+)\s*->\s*([\w_]+)\s*\[\s*label\s*=\s*"([^"]+)"\s*\];`)
-)
+type Options struct {
+	EpsilonAliases []string
+}
+
+func DefaultOptions() Options {
+	return Options{EpsilonAliases: dot.DefaultEpsilonAliases}
+}
 
 type parser struct {
-	startState      string
+	options         Options
+	startStates     []string
 	acceptingStates map[string]bool
 	transitions     map[string]map[string][]string
 	allStates       map[string]bool
 	alphabetSet     map[string]bool
 }
 
-func newParser() *parser {
+func newParser(options Options) *parser {
 	return &parser{
+		options:         options,
 		acceptingStates: make(map[string]bool),
 		transitions:     make(map[string]map[string][]string),
 		allStates:       make(map[string]bool),
@@ -35,7 +37,11 @@ func newParser() *parser {
 }
 
 func ParseNFA(dotString string) (*model.NFA, error) {
-	p := newParser()
+	return ParseNFAWithOptions(dotString, DefaultOptions())
+}
+
+func ParseNFAWithOptions(dotString string, options Options) (*model.NFA, error) {
+	p := newParser(options)
 	if err := p.parse(dotString); err != nil {
 		return nil, err
 	}
@@ -43,7 +49,11 @@ func ParseNFA(dotString string) (*model.NFA, error) {
 }
 
 func ParseDFA(dotString string) (*model.DFA, error) {
-	p := newParser()
+	return ParseDFAWithOptions(dotString, DefaultOptions())
+}
+
+func ParseDFAWithOptions(dotString string, options Options) (*model.DFA, error) {
+	p := newParser(options)
 	if err := p.parse(dotString); err != nil {
 		return nil, err
 	}
@@ -51,71 +61,76 @@ func ParseDFA(dotString string) (*model.DFA, error) {
 }
 
 func (p *parser) parse(dotString string) error {
-	var fullText strings.Builder
-	scanner := bufio.NewScanner(strings.NewReader(dotString))
-	for scanner.Scan() {
-		fullText.WriteString(scanner.Text())
-		fullText.WriteString("\n")
-	}
-	if err := scanner.Err(); err != nil {
-		return fmt.Errorf("failed to read string: %w", err)
-	}
-
-	text := fullText.String()
-	p.parseAcceptingStates(text)
-	p.parseAllTransitions(text)
-
-	if err := p.parseStartState(text); err != nil {
-		return err
+	automaton, err := dot.ParseAutomaton(dotString)
+	if err != nil {
+		return fmt.Errorf("failed to parse DOT: %w", err)
 	}
 
-	return nil
-}
-
-func (p *parser) parseAcceptingStates(text string) {
-	matches := acceptingStateRegex.FindStringSubmatch(text)
-	if len(matches) < 2 {
-		return
+	for _, state := range automaton.States {
+		p.allStates[state] = true
 	}
-	for _, state := range strings.Fields(matches[1]) {
+	for state := range automaton.Accepting {
 		p.acceptingStates[state] = true
-		p.allStates[state] = true
 	}
-}
+	for _, transition := range automaton.Transitions {
+		symbols, err := p.splitLabel(transition.Label)
+		if err != nil {
+			return fmt.Errorf("%s: %w", transition.Pos, err)
+		}
+		for _, symbol := range symbols {
+			p.addTransition(transition.From, symbol, transition.To)
+		}
+	}
 
-func (p *parser) parseStartState(text string) error {
-	matches := startStateRegex.FindStringSubmatch(text)
-	if len(matches) < 2 {
+	if len(automaton.StartStates) == 0 {
 		return errors.New("start state not found")
 	}
-	p.startState = matches[1]
-	p.allStates[p.startState] = true
+	for _, state := range automaton.StartStates {
+		p.startStates = append(p.startStates, state)
+		p.allStates[state] = true
+	}
+	sort.Strings(p.startStates)
 	return nil
 }
 
-func (p *parser) parseAllTransitions(text string) {
-	matches := transitionRegex.FindAllStringSubmatch(text, -1)
-	for _, match := range matches {
-		from, to, symbol := match[1], match[2], match[3]
+func (p *parser) splitLabel(label string) ([]string, error) {
+	if label == "" && dot.IsEpsilon(label, p.options.EpsilonAliases) {
+		return []string{model.Epsilon}, nil
+	}
 
-		if _, ok := p.transitions[from]; !ok {
-			p.transitions[from] = make(map[string][]string)
+	labelSymbols, err := dot.ParseLabel(label)
+	if err != nil {
+		return nil, err
+	}
+	symbols := make([]string, 0, len(labelSymbols))
+	for _, symbol := range labelSymbols {
+		if !symbol.Literal && dot.IsEpsilon(symbol.Value, p.options.EpsilonAliases) {
+			symbols = append(symbols, model.Epsilon)
+			continue
 		}
-		p.transitions[from][symbol] = append(p.transitions[from][symbol], to)
+		symbols = append(symbols, symbol.Value)
+	}
+	return symbols, nil
+}
 
-		p.allStates[from] = true
-		p.allStates[to] = true
-		p.alphabetSet[symbol] = true
+func (p *parser) addTransition(from, symbol, to string) {
+	if _, ok := p.transitions[from]; !ok {
+		p.transitions[from] = make(map[string][]string)
 	}
+	p.transitions[from][symbol] = append(p.transitions[from][symbol], to)
+
+	p.allStates[from] = true
+	p.allStates[to] = true
+	p.alphabetSet[symbol] = true
 }
 
 func (p *parser) buildNFA() (*model.NFA, error) {
-	if p.startState == "" {
-		return nil, errors.New("cannot finalize DFA: start state not found")
+	if len(p.startStates) == 0 {
+		return nil, errors.New("cannot finalize NFA: start state not found")
 	}
 
 	nfa := model.NewNFA()
-	nfa.StartState = p.startState
+	nfa.StartStates = p.startStates
 	nfa.AcceptingStates = p.acceptingStates
 	nfa.Transitions = p.transitions
 
@@ -133,17 +148,23 @@ func (p *parser) buildNFA() (*model.NFA, error) {
 }
 
 func (p *parser) buildDFA() (*model.DFA, error) {
-	if p.startState == "" {
+	if len(p.startStates) == 0 {
 		return nil, errors.New("cannot finalize DFA: start state not found")
 	}
+	if len(p.startStates) > 1 {
+		return nil, fmt.Errorf("failed to parse DFA: multiple start states %v", p.startStates)
+	}
 
 	dfa := model.NewDFA()
-	dfa.StartState = p.startState
+	dfa.StartState = p.startStates[0]
 	dfa.AcceptingStates = p.acceptingStates
 
 	for from, transitions := range p.transitions {
 		dfa.Transitions[from] = make(map[string]string)
 		for symbol, toStates := range transitions {
+			if symbol == model.Epsilon {
+				return nil, fmt.Errorf("failed to parse DFA: epsilon transition from '%s'", from)
+			}
 			if len(toStates) > 1 {
 				return nil, fmt.Errorf("failed to parse DFA: nondeterministic transition from '%s' by symbol '%s'", from, symbol)
 			}
@@ -4,220 +4,193 @@ import (
 	"bufio"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
+	"determinizer/pkg/grammar"
 	"determinizer/pkg/model"
+	"dot/pkg/dot"
 )
 
 const (
-	finalStateName = "H"
-	newStartState  = "F"
+	ruleArrow       = "->"
+	directivePrefix = "%"
+	startDirective  = "%start"
 )
 
-type production struct {
-	terminal      string
-	nonTerminal   string
-	isRightLinear bool
-}
-
 type grammarParser struct {
-	rules         map[string][]production
-	nonTerminals  map[string]bool
-	startSymbol   string
-	hasLeftLinear bool
+	grammar      *model.Grammar
+	nonTerminals map[string]bool
 }
 
 func ParseGrammarToNFA(grammarString string) (*model.NFA, error) {
+	g, err := ParseGrammar(grammarString)
+	if err != nil {
+		return nil, err
+	}
+	return grammar.ToNFA(g)
+}
+
+func ParseGrammar(grammarString string) (*model.Grammar, error) {
 	p := &grammarParser{
-		rules:        make(map[string][]production),
+		grammar:      model.NewGrammar(model.RightLinear),
 		nonTerminals: make(map[string]bool),
 	}
 
 	if err := p.parseAndAnalyze(grammarString); err != nil {
 		return nil, err
 	}
-
-	return p.buildNFA()
+	p.grammar.Kind = grammar.Analyze(p.grammar).Kind
+	return p.grammar, nil
 }
 
 func (p *grammarParser) parseAndAnalyze(grammarString string) error {
+	explicitStart := ""
 	preScanner := bufio.NewScanner(strings.NewReader(grammarString))
 	for preScanner.Scan() {
 		line := strings.TrimSpace(preScanner.Text())
 		if line == "" {
 			continue
 		}
-		parts := strings.Split(line, "->")
-		if len(parts) == 2 {
-			nonTerminal := strings.TrimSpace(parts[0])
+		if strings.HasPrefix(line, directivePrefix) {
+			start, err := parseDirective(line, explicitStart)
+			if err != nil {
+				return err
+			}
+			explicitStart = start
+			continue
+		}
+		if lhs, _, found := strings.Cut(line, ruleArrow); found {
+			nonTerminal := nonTerminalName(lhs)
 			p.nonTerminals[nonTerminal] = true
+			if p.grammar.Start == "" {
+				p.grammar.Start = nonTerminal
+			}
 		}
 	}
 
 	scanner := bufio.NewScanner(strings.NewReader(grammarString))
-	isFirstRule := true
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
-		if line == "" {
+		if line == "" || strings.HasPrefix(line, directivePrefix) {
 			continue
 		}
-		if isFirstRule {
-			parts := strings.Split(line, "->")
-			if len(parts) == 2 {
-				p.startSymbol = strings.TrimSpace(parts[0])
-				isFirstRule = false
-			}
-		}
-
 		if err := p.parseLine(line); err != nil {
 			return err
 		}
 	}
 
-	if p.startSymbol == "" {
+	if p.grammar.Start == "" {
 		return errors.New("empty grammar")
 	}
+	if explicitStart != "" {
+		if !p.isNonTerminal(explicitStart) {
+			return fmt.Errorf("начальный символ %s не определён ни одним правилом", explicitStart)
+		}
+		p.grammar.Start = explicitStart
+	}
 	return nil
 }
 
+func parseDirective(line, currentStart string) (string, error) {
+	name := strings.Fields(line)[0]
+	argument := strings.TrimPrefix(line, name)
+	if name != startDirective {
+		return "", fmt.Errorf("неизвестная директива: %s", line)
+	}
+	start := nonTerminalName(argument)
+	if start == "" {
+		return "", fmt.Errorf("в директиве %s не указан начальный символ", startDirective)
+	}
+	if currentStart != "" {
+		return "", fmt.Errorf("директива %s указана несколько раз", startDirective)
+	}
+	return start, nil
+}
+
 func (p *grammarParser) parseLine(line string) error {
-	parts := strings.Split(line, "->")
-	if len(parts) != 2 {
+	lhs, rhs, found := strings.Cut(line, ruleArrow)
+	if !found {
 		return fmt.Errorf("некорректная строка: %s", line)
 	}
 
-	nonTerminal := strings.TrimSpace(parts[0])
+	nonTerminal := nonTerminalName(lhs)
+	if nonTerminal == "" {
+		return fmt.Errorf("некорректная строка: %s", line)
+	}
+	if _, exists := p.grammar.Productions[nonTerminal]; !exists {
+		p.grammar.NonTerminals = append(p.grammar.NonTerminals, nonTerminal)
+		p.grammar.Productions[nonTerminal] = nil
+	}
 
-	productionsStr := strings.Split(parts[1], "|")
-	for _, prodStr := range productionsStr {
-		if err := p.addProduction(nonTerminal, strings.TrimSpace(prodStr)); err != nil {
+	alternatives, err := splitAlternatives(rhs)
+	if err != nil {
+		return err
+	}
+	for _, tokens := range alternatives {
+		if err := p.addProduction(nonTerminal, tokens); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (p *grammarParser) addProduction(from, prodStr string) error {
-	if prodStr == "eps" {
-		p.rules[from] = append(p.rules[from], production{terminal: "eps"})
-		return nil
-	}
-	if len(prodStr) == 1 {
-		p.rules[from] = append(p.rules[from], production{terminal: prodStr, isRightLinear: true})
+func (p *grammarParser) addProduction(from string, tokens []grammarToken) error {
+	if len(tokens) == 0 || len(tokens) == 1 && tokens[0].kind == wordToken && dot.IsEpsilon(tokens[0].value, dot.DefaultEpsilonAliases) {
+		p.grammar.Productions[from] = append(p.grammar.Productions[from], model.Production{})
 		return nil
 	}
-	if len(prodStr) != 2 {
-		return fmt.Errorf("rule must have 1 or 2 symbols: %s -> %s", from, prodStr)
-	}
-
-	if p.isNonTerminal(string(prodStr[1])) {
-		p.rules[from] = append(p.rules[from], production{
-			terminal:      string(prodStr[0]),
-			nonTerminal:   string(prodStr[1]),
-			isRightLinear: true,
-		})
-	} else if p.isNonTerminal(string(prodStr[0])) {
-		p.hasLeftLinear = true
-		p.rules[from] = append(p.rules[from], production{
-			terminal:      string(prodStr[1]),
-			nonTerminal:   string(prodStr[0]),
-			isRightLinear: false,
-		})
-	} else {
-		return fmt.Errorf("invalid rule: %s -> %s", from, prodStr)
+	if len(tokens) == 1 && tokens[0].kind == wordToken {
+		tokens = p.splitLegacyProduction(tokens[0].value)
 	}
-	return nil
-}
 
-func (p *grammarParser) isNonTerminal(s string) bool {
-	_, exists := p.nonTerminals[s]
-	return exists
-}
-
-func (p *grammarParser) buildNFA() (*model.NFA, error) {
-	if p.hasLeftLinear {
-		return p.buildNFAForLeftLinear()
+	var prod model.Production
+	for _, token := range tokens {
+		if token.kind == nonTerminalToken || token.kind == wordToken && p.isNonTerminal(token.value) {
+			prod.Symbols = append(prod.Symbols, model.NonTerminal(token.value))
+			continue
+		}
+		if token.value == model.Epsilon {
+			return fmt.Errorf("пустой терминал: %s -> %s", from, formatTokens(tokens))
+		}
+		prod.Symbols = append(prod.Symbols, model.Terminal(token.value))
 	}
-	return p.buildNFAForRightLinear()
+	p.grammar.Productions[from] = append(p.grammar.Productions[from], prod)
+	return nil
 }
 
-func (p *grammarParser) buildNFAForRightLinear() (*model.NFA, error) {
-	nfa := model.NewNFA()
-	nfa.StartState = p.startSymbol
-	nfa.AcceptingStates[finalStateName] = true
-	alphabetSet := make(map[string]bool)
-
-	for from, productions := range p.rules {
-		for _, prod := range productions {
-			if prod.terminal == "eps" {
-				nfa.AcceptingStates[from] = true
-				continue
-			}
-
-			to := prod.nonTerminal
-			if to == "" {
-				to = finalStateName
-			}
-			if _, ok := nfa.Transitions[from]; !ok {
-				nfa.Transitions[from] = make(map[string][]string)
-			}
-			nfa.Transitions[from][prod.terminal] = append(nfa.Transitions[from][prod.terminal], to)
-
-			alphabetSet[prod.terminal] = true
-		}
+func (p *grammarParser) splitLegacyProduction(word string) []grammarToken {
+	if utf8.RuneCountInString(word) != 2 || p.isNonTerminal(word) {
+		return []grammarToken{{kind: wordToken, value: word}}
 	}
-	for nt := range p.nonTerminals {
-		nfa.States = append(nfa.States, nt)
+	runes := []rune(word)
+	first, second := string(runes[0]), string(runes[1])
+	if p.isNonTerminal(second) {
+		return []grammarToken{{kind: quotedToken, value: first}, {kind: nonTerminalToken, value: second}}
 	}
-	nfa.States = append(nfa.States, finalStateName)
-
-	for term := range alphabetSet {
-		nfa.Alphabet = append(nfa.Alphabet, term)
+	if p.isNonTerminal(first) {
+		return []grammarToken{{kind: nonTerminalToken, value: first}, {kind: quotedToken, value: second}}
 	}
-
-	return nfa, nil
+	return []grammarToken{{kind: wordToken, value: word}}
 }
 
-func (p *grammarParser) buildNFAForLeftLinear() (*model.NFA, error) {
-	nfa := model.NewNFA()
-	nfa.StartState = newStartState
-	nfa.AcceptingStates[p.startSymbol] = true
-	alphabetSet := make(map[string]bool)
-
-	for from, productions := range p.rules {
-		for _, prod := range productions {
-			if prod.terminal == "eps" {
-				nfa.AcceptingStates[from] = true
-				continue
-			}
-
-			if prod.isRightLinear && prod.nonTerminal != "" {
-				return nil, fmt.Errorf("смешанные грамматики (с правилами вида aB и Ba) не поддерживаются")
-			}
-
-			fromState := prod.nonTerminal
-			toState := from
-			if fromState == "" {
-				fromState = newStartState
-			}
-
-			if _, ok := nfa.Transitions[fromState]; !ok {
-				nfa.Transitions[fromState] = make(map[string][]string)
-			}
-			nfa.Transitions[fromState][prod.terminal] = append(nfa.Transitions[fromState][prod.terminal], toState)
+func (p *grammarParser) isNonTerminal(s string) bool {
+	_, exists := p.nonTerminals[s]
+	return exists
+}
 
-			alphabetSet[prod.terminal] = true
+func formatTokens(tokens []grammarToken) string {
+	parts := make([]string, len(tokens))
+	for i, token := range tokens {
+		switch token.kind {
+		case nonTerminalToken:
+			parts[i] = "<" + token.value + ">"
+		case quotedToken:
+			parts[i] = strconv.Quote(token.value)
+		default:
+			parts[i] = token.value
 		}
 	}
-
-	for nt := range p.nonTerminals {
-		nfa.States = append(nfa.States, nt)
-	}
-	nfa.States = append(nfa.States, newStartState)
-
-	for term := range alphabetSet {
-		nfa.Alphabet = append(nfa.Alphabet, term)
-	}
-
-	return nfa, nil
+	return strings.Join(parts, " ")
 }
@@ -0,0 +1,102 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type grammarTokenKind int
+
+const (
+	wordToken grammarTokenKind = iota
+	nonTerminalToken
+	quotedToken
+)
+
+type grammarToken struct {
+	kind  grammarTokenKind
+	value string
+}
+
+func splitAlternatives(rhs string) ([][]grammarToken, error) {
+	alternatives := [][]grammarToken{nil}
+	runes := []rune(rhs)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '|':
+			alternatives = append(alternatives, nil)
+			i++
+		case r == '<':
+			end := indexRune(runes, i+1, '>')
+			if end < 0 {
+				return nil, fmt.Errorf("незакрытый нетерминал: %s", string(runes[i:]))
+			}
+			name := strings.TrimSpace(string(runes[i+1 : end]))
+			if name == "" {
+				return nil, fmt.Errorf("пустое имя нетерминала: %s", rhs)
+			}
+			last := len(alternatives) - 1
+			alternatives[last] = append(alternatives[last], grammarToken{kind: nonTerminalToken, value: name})
+			i = end + 1
+		case r == '"' || r == '\'':
+			value, next, err := readQuoted(runes, i)
+			if err != nil {
+				return nil, err
+			}
+			last := len(alternatives) - 1
+			alternatives[last] = append(alternatives[last], grammarToken{kind: quotedToken, value: value})
+			i = next
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && !strings.ContainsRune("|<\"'", runes[i]) {
+				i++
+			}
+			last := len(alternatives) - 1
+			alternatives[last] = append(alternatives[last], grammarToken{kind: wordToken, value: string(runes[start:i])})
+		}
+	}
+	return alternatives, nil
+}
+
+func readQuoted(runes []rune, start int) (string, int, error) {
+	quote := runes[start]
+	var builder strings.Builder
+	for i := start + 1; i < len(runes); i++ {
+		switch runes[i] {
+		case '\\':
+			if i+1 < len(runes) {
+				i++
+				builder.WriteRune(runes[i])
+			}
+		case quote:
+			if builder.Len() == 0 {
+				return "", 0, fmt.Errorf("пустой терминал: %s", string(runes[start:i+1]))
+			}
+			return builder.String(), i + 1, nil
+		default:
+			builder.WriteRune(runes[i])
+		}
+	}
+	return "", 0, fmt.Errorf("незакрытая кавычка: %s", string(runes[start:]))
+}
+
+func indexRune(runes []rune, from int, target rune) int {
+	for i := from; i < len(runes); i++ {
+		if runes[i] == target {
+			return i
+		}
+	}
+	return -1
+}
+
+func nonTerminalName(lhs string) string {
+	lhs = strings.TrimSpace(lhs)
+	if strings.HasPrefix(lhs, "<") && strings.HasSuffix(lhs, ">") {
+		return strings.TrimSpace(lhs[1 : len(lhs)-1])
+	}
+	return lhs
+}
@@ -0,0 +1,123 @@
+package tests
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"determinizer/pkg/binfmt"
+	"determinizer/pkg/determinizer"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	testCases := []struct {
+		name      string
+		inputFile string
+	}{
+		{"Simple NFA", "simple_nfa.dot"},
+		{"Hard NFA", "hard_nfa.dot"},
+		{"Merge Paths", "merge_paths_nfa.dot"},
+		{"Overlapping Paths", "overlapping_paths_nfa.dot"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			nfa := parseNFAFile(t, filepath.Join(testNFADataDir, tc.inputFile))
+			expectedDFA := determinizer.NewDeterminizer(nfa).Run()
+
+			data, err := binfmt.Encode(expectedDFA)
+			if err != nil {
+				t.Fatalf("Не удалось закодировать ДКА: %v", err)
+			}
+			if !binfmt.IsBinary(data) {
+				t.Errorf("Закодированные данные не распознаются как двоичный ДКА")
+			}
+
+			actualDFA, err := binfmt.Decode(data)
+			if err != nil {
+				t.Fatalf("Не удалось декодировать ДКА: %v", err)
+			}
+			assertDFAEqual(t, expectedDFA, actualDFA)
+		})
+	}
+}
+
+func TestBinaryLoadFromFile(t *testing.T) {
+	nfa := parseNFAFile(t, filepath.Join(testNFADataDir, "hard_nfa.dot"))
+	expectedDFA := determinizer.NewDeterminizer(nfa).Run()
+
+	filePath := filepath.Join(t.TempDir(), "hard_dfa.bin")
+	if err := binfmt.WriteToFile(expectedDFA, filePath); err != nil {
+		t.Fatalf("Не удалось записать двоичный ДКА: %v", err)
+	}
+
+	actualDFA, err := binfmt.Load(filePath)
+	if err != nil {
+		t.Fatalf("Не удалось загрузить двоичный ДКА: %v", err)
+	}
+	assertDFAEqual(t, expectedDFA, actualDFA)
+
+	f, err := binfmt.Open(filePath)
+	if err != nil {
+		t.Fatalf("Не удалось открыть двоичный ДКА: %v", err)
+	}
+	defer f.Close()
+
+	if f.NumStates() != len(expectedDFA.States) {
+		t.Errorf("Несовпадение числа состояний: ожидалось %d, получено %d", len(expectedDFA.States), f.NumStates())
+	}
+	for state := uint32(0); state < uint32(f.NumStates()); state++ {
+		name := f.StateName(state)
+		if f.IsAccepting(state) != expectedDFA.AcceptingStates[name] {
+			t.Errorf("Несовпадение допускающего состояния %q", name)
+		}
+		for symbolIndex, symbol := range f.Alphabet() {
+			to, ok := f.Next(state, symbolIndex)
+			expected, exists := expectedDFA.Transitions[name][symbol]
+			if ok != exists || (ok && f.StateName(to) != expected) {
+				t.Errorf("Несовпадение перехода из %q по %q", name, symbol)
+			}
+		}
+	}
+}
+
+func TestBinaryRejectsCorruptedData(t *testing.T) {
+	nfa := parseNFAFile(t, filepath.Join(testNFADataDir, "simple_nfa.dot"))
+	data, err := binfmt.Encode(determinizer.NewDeterminizer(nfa).Run())
+	if err != nil {
+		t.Fatalf("Не удалось закодировать ДКА: %v", err)
+	}
+
+	corrupted := append([]byte(nil), data...)
+	corrupted[len(corrupted)/2] ^= 0xFF
+	if _, err = binfmt.Decode(corrupted); err == nil {
+		t.Errorf("Ожидалась ошибка контрольной суммы для повреждённых данных")
+	}
+	if _, err = binfmt.Decode(data[:len(data)-1]); err == nil {
+		t.Errorf("Ожидалась ошибка для усечённых данных")
+	}
+
+	invalidTarget := append([]byte(nil), data...)
+	binary.LittleEndian.PutUint32(invalidTarget[20:], 1000)
+	binary.LittleEndian.PutUint32(invalidTarget[len(invalidTarget)-4:], crc32.ChecksumIEEE(invalidTarget[:len(invalidTarget)-4]))
+	if _, err = binfmt.Decode(invalidTarget); err == nil {
+		t.Errorf("Ожидалась ошибка для перехода в несуществующее состояние")
+	}
+	filePath := filepath.Join(t.TempDir(), "invalid.bin")
+	if err = os.WriteFile(filePath, invalidTarget, 0644); err != nil {
+		t.Fatalf("Не удалось создать файл: %v", err)
+	}
+	if _, err = binfmt.Open(filePath); err == nil {
+		t.Errorf("Ожидалась ошибка при открытии файла с переходом в несуществующее состояние")
+	}
+
+	filePath = filepath.Join(t.TempDir(), "empty.bin")
+	if err = os.WriteFile(filePath, nil, 0644); err != nil {
+		t.Fatalf("Не удалось создать файл: %v", err)
+	}
+	if _, err = binfmt.Load(filePath); err == nil {
+		t.Errorf("Ожидалась ошибка для пустого файла")
+	}
+}
@@ -38,6 +38,8 @@ func TestDeterminize(t *testing.T) {
 		{"Hard NFA", "hard_nfa.dot", "hard_dfa_expected.dot"},
 		{"Merge Paths", "merge_paths_nfa.dot", "merge_paths_dfa_expected.dot"},
 		{"Overlapping Paths", "overlapping_paths_nfa.dot", "overlapping_paths_dfa_expected.dot"},
+		{"Multi-Symbol Labels", "multi_label_nfa.dot", "multi_label_dfa_expected.dot"},
+		{"Multiple Start States", "multi_start_nfa.dot", "multi_start_dfa_expected.dot"},
 	}
 
 	if err := os.MkdirAll(TestOutputDir, 0755); err != nil {
@@ -0,0 +1,124 @@
+package tests
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"determinizer/pkg/determinizer"
+	"determinizer/pkg/model"
+	"determinizer/pkg/parser"
+	"determinizer/pkg/transform"
+)
+
+func TestEpsilonAliases(t *testing.T) {
+	nfa := parseNFAFile(t, filepath.Join(testNFADataDir, "epsilon_aliases_nfa.dot"))
+
+	expectedAlphabet := []string{model.Epsilon, "a", "b"}
+	if !reflect.DeepEqual(nfa.Alphabet, expectedAlphabet) {
+		t.Errorf("Несовпадение Alphabet: ожидалось %v, получено %v", expectedAlphabet, nfa.Alphabet)
+	}
+
+	actualDFA := determinizer.NewDeterminizer(nfa).Run()
+	expectedDFA := parseDFAFile(t, filepath.Join(testNFADataDir, "epsilon_aliases_dfa_expected.dot"))
+	assertDFAEqual(t, expectedDFA, actualDFA)
+}
+
+func TestCustomEpsilonAliases(t *testing.T) {
+	input := `digraph {
+  start -> q0;
+  q2 [shape=doublecircle];
+  q0 -> q1 [label="eps"];
+  q1 -> q2 [label="~"];
+}`
+
+	nfa, err := parser.ParseNFAWithOptions(input, parser.Options{EpsilonAliases: []string{"~"}})
+	if err != nil {
+		t.Fatalf("Не удалось распарсить НКА: %v", err)
+	}
+	expectedTransitions := map[string]map[string][]string{
+		"q0": {"eps": {"q1"}},
+		"q1": {model.Epsilon: {"q2"}},
+	}
+	if !reflect.DeepEqual(nfa.Transitions, expectedTransitions) {
+		t.Errorf("Несовпадение Transitions: ожидалось %v, получено %v", expectedTransitions, nfa.Transitions)
+	}
+
+	dfa := determinizer.NewDeterminizer(nfa).Run()
+	if !reflect.DeepEqual(dfa.Alphabet, []string{"eps"}) {
+		t.Errorf("Несовпадение Alphabet: ожидалось [eps], получено %v", dfa.Alphabet)
+	}
+	if !acceptsDFA(dfa, []string{"eps"}) || acceptsDFA(dfa, nil) {
+		t.Errorf("ДКА должен принимать только слово из символа eps")
+	}
+}
+
+func TestParseDFARejectsEpsilon(t *testing.T) {
+	input := `digraph {
+  start -> q0;
+  q0 -> q1 [label="ε"];
+}`
+
+	if _, err := parser.ParseDFA(input); err == nil {
+		t.Errorf("Ожидалась ошибка для ДКА с ε-переходом")
+	}
+}
+
+func TestRemoveEpsilon(t *testing.T) {
+	testCases := []string{
+		"hard_nfa.dot",
+		"merge_paths_nfa.dot",
+		"multi_start_nfa.dot",
+		"epsilon_aliases_nfa.dot",
+	}
+
+	for _, inputFile := range testCases {
+		t.Run(inputFile, func(t *testing.T) {
+			nfa := parseNFAFile(t, filepath.Join(testNFADataDir, inputFile))
+			epsilonFree := transform.RemoveEpsilon(nfa)
+
+			for from, transitions := range epsilonFree.Transitions {
+				if _, ok := transitions[model.Epsilon]; ok {
+					t.Errorf("Остался ε-переход из %q", from)
+				}
+			}
+
+			expectedDFA := determinizer.NewDeterminizer(nfa).Run()
+			actualDFA := determinizer.NewDeterminizer(epsilonFree).Run()
+			for _, word := range allWords(expectedDFA.Alphabet, 6) {
+				if acceptsDFA(expectedDFA, word) != acceptsDFA(actualDFA, word) {
+					t.Errorf("Несовпадение языков на слове %v", word)
+				}
+			}
+		})
+	}
+}
+
+func acceptsDFA(dfa *model.DFA, word []string) bool {
+	current := dfa.StartState
+	for _, symbol := range word {
+		next, ok := dfa.Transitions[current][symbol]
+		if !ok {
+			return false
+		}
+		current = next
+	}
+	return dfa.AcceptingStates[current]
+}
+
+func allWords(alphabet []string, maxLength int) [][]string {
+	words := [][]string{{}}
+	layer := [][]string{{}}
+	for length := 1; length <= maxLength; length++ {
+		var next [][]string
+		for _, prefix := range layer {
+			for _, symbol := range alphabet {
+				word := append(append([]string(nil), prefix...), symbol)
+				next = append(next, word)
+			}
+		}
+		words = append(words, next...)
+		layer = next
+	}
+	return words
+}
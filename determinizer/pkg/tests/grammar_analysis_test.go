@@ -0,0 +1,224 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"determinizer/pkg/determinizer"
+	"determinizer/pkg/grammar"
+	"determinizer/pkg/model"
+	"determinizer/pkg/parser"
+	"determinizer/pkg/writer"
+)
+
+func TestGrammarClassification(t *testing.T) {
+	testCases := []struct {
+		name     string
+		grammar  string
+		expected model.GrammarKind
+	}{
+		{"Right-linear", "S -> aA | b\nA -> bS | eps\n", model.RightLinear},
+		{"Left-linear", "S -> Aa | b\nA -> Sb\n", model.LeftLinear},
+		{"Terminals only", "<S> -> if | then | eps\n", model.RightLinear},
+		{"Unit productions", "<S> -> <A> | a\n<A> -> b\n", model.RightLinear},
+		{"Mixed", "<S> -> a <A>\n<A> -> <S> b | c\n", model.MixedLinear},
+		{"Two nonterminals", "<S> -> <S> <S> | a\n", model.NonRegular},
+		{"Nonterminal in middle", "<S> -> a <S> b | ab\n", model.NonRegular},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g, err := parser.ParseGrammar(tc.grammar)
+			if err != nil {
+				t.Fatalf("Ошибка парсинга грамматики: %v", err)
+			}
+			if g.Kind != tc.expected {
+				t.Errorf("Несовпадение типа грамматики: ожидалось %q, получено %q", tc.expected, g.Kind)
+			}
+			if kind := grammar.Analyze(g).Kind; kind != tc.expected {
+				t.Errorf("Несовпадение типа в анализе: ожидалось %q, получено %q", tc.expected, kind)
+			}
+		})
+	}
+}
+
+func TestGrammarDiagnostics(t *testing.T) {
+	testCases := []struct {
+		name     string
+		grammar  string
+		expected []string
+	}{
+		{"Mixed", "<S> -> a <A>\n<A> -> <S> b | c\n", []string{"смешанная", `<S> -> "a" <A>`, `<A> -> <S> "b"`}},
+		{"Two nonterminals", "<S> -> <S> <S> | a\n", []string{"не является регулярной", "<S> -> <S> <S>", "больше одного нетерминала"}},
+		{"Nonterminal in middle", "<S> -> a <S> b | c\n", []string{"не является регулярной", `<S> -> "a" <S> "b"`, "в середине"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parser.ParseGrammarToNFA(tc.grammar)
+			if err == nil {
+				t.Fatalf("Ожидалась ошибка для грамматики %q", tc.grammar)
+			}
+			for _, part := range tc.expected {
+				if !strings.Contains(err.Error(), part) {
+					t.Errorf("Сообщение %q не содержит %q", err.Error(), part)
+				}
+			}
+		})
+	}
+}
+
+func TestGrammarUselessNonTerminals(t *testing.T) {
+	g, err := parser.ParseGrammar("<Num> -> <Num> d | d | <Lost> q | <Missing> x\n<Junk> -> x <Junk> | y\n<Lost> -> <Lost> z\n")
+	if err != nil {
+		t.Fatalf("Ошибка парсинга грамматики: %v", err)
+	}
+	analysis := grammar.Analyze(g)
+
+	if analysis.Kind != model.MixedLinear {
+		t.Errorf("Несовпадение типа грамматики: ожидалось %q, получено %q", model.MixedLinear, analysis.Kind)
+	}
+	if !reflect.DeepEqual(analysis.Unreachable, []string{"Junk"}) {
+		t.Errorf("Несовпадение недостижимых нетерминалов: получено %v", analysis.Unreachable)
+	}
+	if !reflect.DeepEqual(analysis.Unproductive, []string{"Lost"}) {
+		t.Errorf("Несовпадение непродуктивных нетерминалов: получено %v", analysis.Unproductive)
+	}
+	if !reflect.DeepEqual(analysis.Undefined, []string{"Missing"}) {
+		t.Errorf("Несовпадение неопределённых нетерминалов: получено %v", analysis.Undefined)
+	}
+
+	normalized := grammar.Normalize(g)
+	if normalized.Kind != model.LeftLinear {
+		t.Errorf("Несовпадение типа нормализованной грамматики: ожидалось %q, получено %q", model.LeftLinear, normalized.Kind)
+	}
+	if !reflect.DeepEqual(normalized.NonTerminals, []string{"Num"}) {
+		t.Errorf("Несовпадение нетерминалов после нормализации: получено %v", normalized.NonTerminals)
+	}
+	if len(normalized.Productions["Num"]) != 2 {
+		t.Errorf("Ожидалось 2 правила для Num, получено %v", normalized.Productions["Num"])
+	}
+	if _, err = grammar.ToNFA(normalized); err != nil {
+		t.Errorf("Ошибка построения НКА по нормализованной грамматике: %v", err)
+	}
+}
+
+func TestGrammarRejectsUndefinedNonTerminals(t *testing.T) {
+	text := "<S> -> a <X> | b\n"
+	if _, err := parser.ParseGrammarToNFA(text); err == nil || !strings.Contains(err.Error(), "<X>") {
+		t.Errorf("Ожидалась ошибка о неопределённом нетерминале X, получено %v", err)
+	}
+
+	g, err := parser.ParseGrammar(text)
+	if err != nil {
+		t.Fatalf("Ошибка парсинга грамматики: %v", err)
+	}
+	nfa, err := grammar.ToNFA(grammar.Normalize(g))
+	if err != nil {
+		t.Fatalf("Ошибка построения НКА по нормализованной грамматике: %v", err)
+	}
+	for _, transitions := range nfa.Transitions {
+		for _, targets := range transitions {
+			for _, to := range targets {
+				if !slices.Contains(nfa.States, to) {
+					t.Errorf("Переход ведёт в состояние %q, которого нет в States", to)
+				}
+			}
+		}
+	}
+	dfa := determinizer.NewDeterminizer(nfa).Run()
+	if !acceptsDFA(dfa, []string{"b"}) || acceptsDFA(dfa, []string{"a"}) {
+		t.Errorf("Нормализованная грамматика должна принимать только слово b")
+	}
+}
+
+func TestGrammarStartDirective(t *testing.T) {
+	g, err := parser.ParseGrammar("<Digit> -> 0 | 1\n%start <Number>\n<Number> -> 0 <Number> | 1 <Number> | 0 | 1\n")
+	if err != nil {
+		t.Fatalf("Ошибка парсинга грамматики: %v", err)
+	}
+	if g.Start != "Number" {
+		t.Errorf("Несовпадение начального символа: ожидалось Number, получено %q", g.Start)
+	}
+	if analysis := grammar.Analyze(g); !reflect.DeepEqual(analysis.Unreachable, []string{"Digit"}) {
+		t.Errorf("Несовпадение недостижимых нетерминалов: получено %v", analysis.Unreachable)
+	}
+
+	nfa, err := grammar.ToNFA(g)
+	if err != nil {
+		t.Fatalf("Ошибка построения НКА: %v", err)
+	}
+	dfa := determinizer.NewDeterminizer(nfa).Run()
+	if !acceptsDFA(dfa, []string{"1", "0", "1"}) {
+		t.Errorf("Слово 101 должно приниматься")
+	}
+
+	errorCases := map[string]string{
+		"Undefined start":   "%start <Missing>\n<S> -> a\n",
+		"Duplicate start":   "%start S\n%start S\nS -> a\n",
+		"Unknown directive": "%token a\nS -> a\n",
+		"Empty directive":   "%start\nS -> a\n",
+	}
+	for name, text := range errorCases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parser.ParseGrammar(text); err == nil {
+				t.Errorf("Ожидалась ошибка для грамматики %q", text)
+			}
+		})
+	}
+}
+
+func TestGrammarLinearityConversion(t *testing.T) {
+	testFiles := []string{"right_linear_grammar.txt", "left_linear_grammar.txt", "epsilon_grammar.txt"}
+	conversions := []struct {
+		kind    model.GrammarKind
+		convert func(*model.Grammar) (*model.Grammar, error)
+	}{
+		{model.RightLinear, grammar.ToRightLinear},
+		{model.LeftLinear, grammar.ToLeftLinear},
+	}
+
+	for _, file := range testFiles {
+		for _, conversion := range conversions {
+			t.Run(string(conversion.kind)+"/"+file, func(t *testing.T) {
+				data, err := os.ReadFile(filepath.Join(testGrammarDataDir, file))
+				if err != nil {
+					t.Fatalf("Не удалось прочитать файл грамматики: %v", err)
+				}
+				g, err := parser.ParseGrammar(string(data))
+				if err != nil {
+					t.Fatalf("Ошибка парсинга грамматики: %v", err)
+				}
+				converted, err := conversion.convert(g)
+				if err != nil {
+					t.Fatalf("Ошибка преобразования грамматики: %v", err)
+				}
+				if kind := grammar.Analyze(converted).Kind; kind != conversion.kind {
+					t.Errorf("Несовпадение типа: ожидалось %q, получено %q", conversion.kind, kind)
+				}
+
+				text := writer.FormatGrammar(converted)
+				reparsed, err := parser.ParseGrammarToNFA(text)
+				if err != nil {
+					t.Fatalf("Не удалось распарсить преобразованную грамматику: %v\n%s", err, text)
+				}
+				original, err := grammar.ToNFA(g)
+				if err != nil {
+					t.Fatalf("Ошибка построения НКА: %v", err)
+				}
+				expected := determinizer.NewDeterminizer(original).Run()
+				actual := determinizer.NewDeterminizer(reparsed).Run()
+				for _, word := range allWords(expected.Alphabet, 6) {
+					if acceptsDFA(expected, word) != acceptsDFA(actual, word) {
+						t.Errorf("Преобразованная грамматика по-разному обрабатывает слово %v:\n%s", word, text)
+						return
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"determinizer/pkg/determinizer"
+	"determinizer/pkg/model"
+	"determinizer/pkg/parser"
+	"determinizer/pkg/transform"
+	"determinizer/pkg/writer"
+)
+
+func TestGrammarExportRoundTrip(t *testing.T) {
+	testFiles := []string{
+		"simple_nfa.dot",
+		"hard_nfa.dot",
+		"merge_paths_nfa.dot",
+		"multi_start_nfa.dot",
+		"overlapping_paths_nfa.dot",
+		"epsilon_aliases_nfa.dot",
+	}
+	exporters := map[string]func(*model.NFA) (*model.Grammar, error){
+		"right": transform.RightLinearGrammar,
+		"left":  transform.LeftLinearGrammar,
+	}
+
+	for _, file := range testFiles {
+		for kind, export := range exporters {
+			t.Run(kind+"/"+file, func(t *testing.T) {
+				nfa := parseNFAFile(t, filepath.Join(testNFADataDir, file))
+				expected := determinizer.NewDeterminizer(nfa).Run()
+
+				assertGrammarLanguage(t, export, nfa, expected)
+				assertGrammarLanguage(t, export, transform.DFAToNFA(expected), expected)
+			})
+		}
+	}
+}
+
+func TestGrammarExportFromGrammar(t *testing.T) {
+	testFiles := []string{"right_linear_grammar.txt", "left_linear_grammar.txt", "epsilon_grammar.txt"}
+
+	for _, file := range testFiles {
+		t.Run(file, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join(testGrammarDataDir, file))
+			if err != nil {
+				t.Fatalf("Не удалось прочитать файл грамматики: %v", err)
+			}
+			nfa, err := parser.ParseGrammarToNFA(string(data))
+			if err != nil {
+				t.Fatalf("Ошибка парсинга грамматики: %v", err)
+			}
+			expected := determinizer.NewDeterminizer(nfa).Run()
+
+			assertGrammarLanguage(t, transform.RightLinearGrammar, nfa, expected)
+			assertGrammarLanguage(t, transform.LeftLinearGrammar, nfa, expected)
+		})
+	}
+}
+
+func TestFormatGrammar(t *testing.T) {
+	nfa := parseNFAFile(t, filepath.Join(testNFADataDir, "merge_paths_nfa.dot"))
+
+	testCases := []struct {
+		name     string
+		export   func(*model.NFA) (*model.Grammar, error)
+		expected string
+	}{
+		{"right", transform.RightLinearGrammar, "S -> aA\nA -> bB | cB\nB -> d\n"},
+		{"left", transform.LeftLinearGrammar, "S -> Bd\nA -> a\nB -> Ab | Ac\n"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			grammar, err := tc.export(nfa)
+			if err != nil {
+				t.Fatalf("Ошибка построения грамматики: %v", err)
+			}
+			if actual := writer.FormatGrammar(grammar); actual != tc.expected {
+				t.Errorf("Несовпадение грамматики: \nожидалось %q, \nполучено  %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestLeftLinearEpsilonProduction(t *testing.T) {
+	nfa, err := parser.ParseGrammarToNFA("S -> Sa | Ab\nA -> Ab | eps\n")
+	if err != nil {
+		t.Fatalf("Ошибка парсинга грамматики: %v", err)
+	}
+	dfa := determinizer.NewDeterminizer(nfa).Run()
+
+	testCases := map[string]bool{"": false, "b": true, "bb": true, "ba": true, "bbaa": true, "a": false, "ab": false}
+	for word, expected := range testCases {
+		symbols := make([]string, 0, len(word))
+		for _, r := range word {
+			symbols = append(symbols, string(r))
+		}
+		if acceptsDFA(dfa, symbols) != expected {
+			t.Errorf("Слово %q: ожидалось %v", word, expected)
+		}
+	}
+}
+
+func TestGrammarExportEmptyLanguage(t *testing.T) {
+	empty := model.NewNFA()
+	empty.States = []string{"q0"}
+	empty.StartStates = []string{"q0"}
+	if _, err := transform.RightLinearGrammar(empty); err == nil {
+		t.Errorf("Ожидалась ошибка для пустого языка")
+	}
+}
+
+func assertGrammarLanguage(t *testing.T, export func(*model.NFA) (*model.Grammar, error), nfa *model.NFA, expected *model.DFA) {
+	t.Helper()
+	grammar, err := export(nfa)
+	if err != nil {
+		t.Fatalf("Ошибка построения грамматики: %v", err)
+	}
+	text := writer.FormatGrammar(grammar)
+	reparsed, err := parser.ParseGrammarToNFA(text)
+	if err != nil {
+		t.Fatalf("Не удалось распарсить построенную грамматику: %v\n%s", err, text)
+	}
+	actual := determinizer.NewDeterminizer(reparsed).Run()
+
+	for _, word := range allWords(expected.Alphabet, 5) {
+		if acceptsDFA(expected, word) != acceptsDFA(actual, word) {
+			t.Errorf("Грамматика по-разному обрабатывает слово %v:\n%s", word, text)
+			return
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package tests
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"determinizer/pkg/determinizer"
+	"determinizer/pkg/model"
+	"determinizer/pkg/parser"
+	"determinizer/pkg/transform"
+	"determinizer/pkg/writer"
+)
+
+func TestGrammarSyntax(t *testing.T) {
+	testCases := []struct {
+		name     string
+		grammar  string
+		accepted [][]string
+		rejected [][]string
+	}{
+		{
+			name: "Named nonterminals and keyword terminals",
+			grammar: "<Start> -> if <Cond>\n" +
+				"<Cond> -> x then <Body> | y then <Body>\n" +
+				"<Body> -> go | stop <Body>\n",
+			accepted: [][]string{{"if", "x", "then", "go"}, {"if", "y", "then", "stop", "stop", "go"}},
+			rejected: [][]string{{"if", "x", "go"}, {"if", "then", "go"}, {"x", "then", "go"}},
+		},
+		{
+			name:     "Multi-character terminal followed by nonterminal",
+			grammar:  "A -> abc B\nB -> d | abc B\n",
+			accepted: [][]string{{"abc", "d"}, {"abc", "abc", "d"}},
+			rejected: [][]string{{"a", "b", "c", "d"}, {"d"}},
+		},
+		{
+			name:     "Quoted terminals",
+			grammar:  "<S> -> \"|\" <S> | 'a b' | \"ε\" | \"eps\" | \"<x>\"\n",
+			accepted: [][]string{{"a b"}, {"|", "ε"}, {"eps"}, {"|", "|", "<x>"}},
+			rejected: [][]string{{}, {"|"}},
+		},
+		{
+			name:     "Bare names of declared nonterminals",
+			grammar:  "Digits -> 0 Digits | 1 Digits | 0 | 1\n",
+			accepted: [][]string{{"0"}, {"1", "0", "1"}},
+			rejected: [][]string{{}, {"2"}},
+		},
+		{
+			name:     "Left-linear named grammar",
+			grammar:  "<S> -> <A> b | a\n<A> -> <A> a | a c\n",
+			accepted: [][]string{{"a"}, {"a", "c", "b"}, {"a", "c", "a", "a", "b"}},
+			rejected: [][]string{{"a", "b"}, {"a", "c"}},
+		},
+		{
+			name:     "Unit production",
+			grammar:  "<S> -> a <S> | <T>\n<T> -> b | eps\n",
+			accepted: [][]string{{}, {"a", "a"}, {"a", "b"}, {"b"}},
+			rejected: [][]string{{"b", "a"}},
+		},
+		{
+			name:     "Legacy compact syntax",
+			grammar:  "S -> aA | bS\nA -> bB\nB -> a\n",
+			accepted: [][]string{{"a", "b", "a"}, {"b", "a", "b", "a"}},
+			rejected: [][]string{{"a", "b"}, {"aA"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			nfa, err := parser.ParseGrammarToNFA(tc.grammar)
+			if err != nil {
+				t.Fatalf("Ошибка парсинга грамматики: %v", err)
+			}
+			dfa := determinizer.NewDeterminizer(nfa).Run()
+
+			for _, word := range tc.accepted {
+				if !acceptsDFA(dfa, word) {
+					t.Errorf("Слово %q должно приниматься", word)
+				}
+			}
+			for _, word := range tc.rejected {
+				if acceptsDFA(dfa, word) {
+					t.Errorf("Слово %q не должно приниматься", word)
+				}
+			}
+		})
+	}
+}
+
+func TestGrammarSyntaxErrors(t *testing.T) {
+	testCases := map[string]string{
+		"Two nonterminals":       "<S> -> <A> <B>\n<A> -> a\n<B> -> b\n",
+		"Nonterminal in middle":  "<S> -> a <S> b\n",
+		"Unterminated quote":     "<S> -> \"abc\n",
+		"Unterminated name":      "<S> -> a <S\n",
+		"Mixed linear grammar":   "<S> -> a <A>\n<A> -> <S> b\n",
+		"Missing arrow":          "<S> a <S>\n",
+		"Empty nonterminal name": "<S> -> a <>\n",
+		"Empty terminal":         "<S> -> a \"\"\n",
+	}
+
+	for name, grammar := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parser.ParseGrammarToNFA(grammar); err == nil {
+				t.Errorf("Ожидалась ошибка для грамматики %q", grammar)
+			}
+		})
+	}
+}
+
+func TestGrammarServiceStatesAvoidNonTerminals(t *testing.T) {
+	nfa, err := parser.ParseGrammarToNFA("<H> -> a <F>\n<F> -> b | c <G1>\n<G1> -> x y z\n")
+	if err != nil {
+		t.Fatalf("Ошибка парсинга грамматики: %v", err)
+	}
+	dfa := determinizer.NewDeterminizer(nfa).Run()
+
+	for _, word := range [][]string{{"a", "b"}, {"a", "c", "x", "y", "z"}} {
+		if !acceptsDFA(dfa, word) {
+			t.Errorf("Слово %q должно приниматься", word)
+		}
+	}
+	if acceptsDFA(dfa, []string{"a"}) {
+		t.Errorf("Слово [a] не должно приниматься")
+	}
+}
+
+func TestGrammarExportNamedNonTerminals(t *testing.T) {
+	keywords := model.NewNFA()
+	keywords.States = []string{"start", "cond", "body", "done"}
+	keywords.Alphabet = []string{"if", "then", "x", "|"}
+	keywords.StartStates = []string{"start"}
+	keywords.AcceptingStates["done"] = true
+	keywords.Transitions["start"] = map[string][]string{"if": {"cond"}}
+	keywords.Transitions["cond"] = map[string][]string{"x": {"body"}, "|": {"cond"}}
+	keywords.Transitions["body"] = map[string][]string{"then": {"done"}}
+
+	chain := model.NewNFA()
+	chain.Alphabet = []string{"a"}
+	for i := 0; i <= 30; i++ {
+		chain.States = append(chain.States, fmt.Sprintf("q%d", i))
+		if i > 0 {
+			chain.Transitions[fmt.Sprintf("q%d", i-1)] = map[string][]string{"a": {fmt.Sprintf("q%d", i)}}
+		}
+	}
+	chain.StartStates = []string{"q0"}
+	chain.AcceptingStates["q30"] = true
+
+	for name, nfa := range map[string]*model.NFA{"keywords": keywords, "long chain": chain} {
+		for _, export := range []func(*model.NFA) (*model.Grammar, error){transform.RightLinearGrammar, transform.LeftLinearGrammar} {
+			t.Run(name, func(t *testing.T) {
+				grammar, err := export(nfa)
+				if err != nil {
+					t.Fatalf("Ошибка построения грамматики: %v", err)
+				}
+				text := writer.FormatGrammar(grammar)
+				if !strings.Contains(text, "<") {
+					t.Errorf("Ожидались нетерминалы вида <Name>:\n%s", text)
+				}
+
+				reparsed, err := parser.ParseGrammarToNFA(text)
+				if err != nil {
+					t.Fatalf("Не удалось распарсить построенную грамматику: %v\n%s", err, text)
+				}
+				expected := determinizer.NewDeterminizer(nfa).Run()
+				actual := determinizer.NewDeterminizer(reparsed).Run()
+				for _, word := range allWords(expected.Alphabet, 4) {
+					if acceptsDFA(expected, word) != acceptsDFA(actual, word) {
+						t.Errorf("Грамматика по-разному обрабатывает слово %v:\n%s", word, text)
+					}
+				}
+				if name == "long chain" && !acceptsDFA(actual, strings.Split(strings.Repeat("a", 30), "")) {
+					t.Errorf("Слово из 30 символов a должно приниматься:\n%s", text)
+				}
+			})
+		}
+	}
+}
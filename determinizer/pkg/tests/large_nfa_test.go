@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+
+	"determinizer/pkg/determinizer"
+	"determinizer/pkg/model"
+)
+
+func TestDeterminizeLargeNFA(t *testing.T) {
+	const (
+		wordCount  = 1000
+		wordLength = 6
+	)
+
+	nfa := model.NewNFA()
+	nfa.StartStates = []string{"s"}
+	nfa.Alphabet = []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9", model.Epsilon}
+	addTransition := func(from, symbol, to string) {
+		if _, ok := nfa.Transitions[from]; !ok {
+			nfa.Transitions[from] = make(map[string][]string)
+		}
+		nfa.Transitions[from][symbol] = append(nfa.Transitions[from][symbol], to)
+	}
+
+	prefixes := map[string]bool{"": true}
+	var words []string
+	for i := 0; i < wordCount; i++ {
+		word := fmt.Sprintf("%0*d", wordLength, i*7919%1000003)
+		words = append(words, word)
+
+		previous := fmt.Sprintf("w%d_0", i)
+		addTransition("s", model.Epsilon, previous)
+		nfa.States = append(nfa.States, previous)
+		for j, r := range word {
+			next := fmt.Sprintf("w%d_%d", i, j+1)
+			addTransition(previous, string(r), next)
+			nfa.States = append(nfa.States, next)
+			previous = next
+			prefixes[word[:j+1]] = true
+		}
+		nfa.AcceptingStates[previous] = true
+	}
+	nfa.States = append(nfa.States, "s")
+
+	dfa := determinizer.NewDeterminizer(nfa).Run()
+
+	if len(dfa.States) != len(prefixes) {
+		t.Errorf("Несовпадение числа состояний: ожидалось %d, получено %d", len(prefixes), len(dfa.States))
+	}
+	for _, word := range words {
+		var symbols []string
+		for _, r := range word {
+			symbols = append(symbols, string(r))
+		}
+		if !acceptsDFA(dfa, symbols) {
+			t.Errorf("ДКА не принимает слово %q", word)
+		}
+	}
+	if acceptsDFA(dfa, []string{"0"}) {
+		t.Errorf("ДКА принимает префикс, не являющийся словом")
+	}
+
+	assertDFAEqual(t, dfa, determinizer.NewDeterminizer(nfa).RunParallel(4))
+}
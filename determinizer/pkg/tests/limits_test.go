@@ -0,0 +1,56 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"determinizer/pkg/determinizer"
+)
+
+func TestRunContextStateLimit(t *testing.T) {
+	for _, workers := range []int{1, 4} {
+		nfa := parseNFAFile(t, filepath.Join(testNFADataDir, "hard_nfa.dot"))
+		d := determinizer.NewDeterminizer(nfa)
+
+		var err error
+		if workers == 1 {
+			_, err = d.RunContext(context.Background(), determinizer.Limits{MaxStates: 3})
+		} else {
+			_, err = d.RunParallelContext(context.Background(), workers, determinizer.Limits{MaxStates: 3})
+		}
+
+		var limitErr *determinizer.LimitError
+		if !errors.As(err, &limitErr) {
+			t.Fatalf("Ожидалась ошибка *LimitError, получено %v", err)
+		}
+		if !errors.Is(err, determinizer.ErrStateLimit) {
+			t.Errorf("Ожидалась причина ErrStateLimit, получено %v", limitErr.Err)
+		}
+		if limitErr.Stats.States <= 3 {
+			t.Errorf("Ожидалось больше 3 обнаруженных состояний, получено %d", limitErr.Stats.States)
+		}
+	}
+}
+
+func TestRunContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	nfa := parseNFAFile(t, filepath.Join(testNFADataDir, "hard_nfa.dot"))
+	if _, err := determinizer.NewDeterminizer(nfa).RunContext(ctx, determinizer.Limits{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Ожидалась ошибка context.Canceled, получено %v", err)
+	}
+}
+
+func TestRunContextWithinLimits(t *testing.T) {
+	nfa := parseNFAFile(t, filepath.Join(testNFADataDir, "hard_nfa.dot"))
+	expectedDFA := determinizer.NewDeterminizer(nfa).Run()
+
+	actualDFA, err := determinizer.NewDeterminizer(nfa).RunContext(context.Background(), determinizer.Limits{MaxStates: 100})
+	if err != nil {
+		t.Fatalf("Неожиданная ошибка: %v", err)
+	}
+	assertDFAEqual(t, expectedDFA, actualDFA)
+}
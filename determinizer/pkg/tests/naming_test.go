@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"determinizer/pkg/determinizer"
+	"determinizer/pkg/model"
+	"determinizer/pkg/parser"
+	"determinizer/pkg/writer"
+)
+
+func TestNamingStrategies(t *testing.T) {
+	testCases := []struct {
+		naming         determinizer.NamingStrategy
+		expectedStart  string
+		expectedStates []string
+	}{
+		{determinizer.NamingOriginal, "q0", []string{"q0", "q1_q2", "q3"}},
+		{determinizer.NamingSequential, "D0", []string{"D0", "D1", "D2"}},
+		{determinizer.NamingSet, "{q0}", []string{"{q0}", "{q1,q2}", "{q3}"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.naming), func(t *testing.T) {
+			nfa := parseNFAFile(t, filepath.Join(testNFADataDir, "overlapping_paths_nfa.dot"))
+			d := determinizer.NewDeterminizerWithOptions(nfa, determinizer.Options{Naming: tc.naming})
+			dfa := d.Run()
+
+			if dfa.StartState != tc.expectedStart {
+				t.Errorf("Несовпадение StartState: ожидалось %q, получено %q", tc.expectedStart, dfa.StartState)
+			}
+			if !reflect.DeepEqual(dfa.States, tc.expectedStates) {
+				t.Errorf("Несовпадение States: ожидалось %v, получено %v", tc.expectedStates, dfa.States)
+			}
+
+			expectedMapping := []model.StateMapping{
+				{State: tc.expectedStates[0], Subset: []string{"q0"}},
+				{State: tc.expectedStates[1], Subset: []string{"q1", "q2"}},
+				{State: tc.expectedStates[2], Subset: []string{"q3"}},
+			}
+			if !reflect.DeepEqual(d.StateMapping(), expectedMapping) {
+				t.Errorf("Несовпадение таблицы соответствия: ожидалось %v, получено %v", expectedMapping, d.StateMapping())
+			}
+
+			outputFile := filepath.Join(t.TempDir(), "dfa.dot")
+			w := writer.NewWriterWithOptions(writer.Options{StateMapping: d.StateMapping()})
+			if err := w.WriteToFile(dfa, outputFile); err != nil {
+				t.Fatalf("Не удалось записать ДКА: %v", err)
+			}
+			reparsed := parseDFAFile(t, outputFile)
+			assertDFAEqual(t, dfa, reparsed)
+		})
+	}
+}
+
+func TestStateMappingFiles(t *testing.T) {
+	nfa := parseNFAFile(t, filepath.Join(testNFADataDir, "overlapping_paths_nfa.dot"))
+	d := determinizer.NewDeterminizerWithOptions(nfa, determinizer.Options{Naming: determinizer.NamingSequential})
+	d.Run()
+
+	csvFile := filepath.Join(t.TempDir(), "mapping.csv")
+	if err := writer.WriteMappingCSV(d.StateMapping(), csvFile); err != nil {
+		t.Fatalf("Не удалось записать CSV: %v", err)
+	}
+	csvData, _ := os.ReadFile(csvFile)
+	expectedCSV := "state,subset\nD0,q0\nD1,q1 q2\nD2,q3\n"
+	if string(csvData) != expectedCSV {
+		t.Errorf("Несовпадение CSV: ожидалось %q, получено %q", expectedCSV, csvData)
+	}
+
+	jsonFile := filepath.Join(t.TempDir(), "mapping.json")
+	if err := writer.WriteMappingJSON(d.StateMapping(), jsonFile); err != nil {
+		t.Fatalf("Не удалось записать JSON: %v", err)
+	}
+	jsonData, _ := os.ReadFile(jsonFile)
+	if !strings.Contains(string(jsonData), `"state": "D1"`) || !strings.Contains(string(jsonData), `"q2"`) {
+		t.Errorf("Неожиданное содержимое JSON: %s", jsonData)
+	}
+}
+
+func TestParseNamingStrategy(t *testing.T) {
+	if _, err := determinizer.ParseNamingStrategy("numbers"); err == nil {
+		t.Errorf("Ожидалась ошибка для неизвестной схемы именования")
+	}
+	if _, err := parser.ParseDFA("digraph { start -> \"{q0}\"; \"{q0}\" -> \"{q1,q2}\" [label=\"a\"]; }"); err != nil {
+		t.Errorf("Не удалось распарсить ДКА с именами-множествами: %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"determinizer/pkg/determinizer"
+	"determinizer/pkg/model"
+	"determinizer/pkg/parser"
+	"determinizer/pkg/writer"
+)
+
+func TestNFAWriterRoundTrip(t *testing.T) {
+	testFiles := []string{
+		"simple_nfa.dot",
+		"hard_nfa.dot",
+		"merge_paths_nfa.dot",
+		"multi_start_nfa.dot",
+		"overlapping_paths_nfa.dot",
+	}
+
+	for _, file := range testFiles {
+		for _, options := range []writer.Options{{}, {MergeParallelEdges: true}, {Epsilon: "ε"}} {
+			t.Run(file, func(t *testing.T) {
+				nfa := parseNFAFile(t, filepath.Join(testNFADataDir, file))
+				reparsed := writeAndParseNFA(t, nfa, options)
+
+				if !reflect.DeepEqual(nfa.StartStates, reparsed.StartStates) {
+					t.Errorf("Несовпадение StartStates: ожидалось %v, получено %v", nfa.StartStates, reparsed.StartStates)
+				}
+				if !reflect.DeepEqual(nfa.AcceptingStates, reparsed.AcceptingStates) {
+					t.Errorf("Несовпадение AcceptingStates: ожидалось %v, получено %v", nfa.AcceptingStates, reparsed.AcceptingStates)
+				}
+				if !reflect.DeepEqual(sortedNFATransitions(nfa), sortedNFATransitions(reparsed)) {
+					t.Errorf("Несовпадение Transitions: \nожидалось %v, \nполучено  %v", nfa.Transitions, reparsed.Transitions)
+				}
+				assertDFAEqual(t, determinizer.NewDeterminizer(nfa).Run(), determinizer.NewDeterminizer(reparsed).Run())
+			})
+		}
+	}
+}
+
+func TestNFAWriterGrammar(t *testing.T) {
+	nfa, err := parser.ParseGrammarToNFA("S -> aA | b\nA -> aA | eps\n")
+	if err != nil {
+		t.Fatalf("Ошибка парсинга грамматики: %v", err)
+	}
+	reparsed := writeAndParseNFA(t, nfa, writer.Options{})
+
+	expected := map[string]map[string][]string{
+		"A": {"a": {"A"}},
+		"S": {"a": {"A"}, "b": {"H"}},
+	}
+	if !reflect.DeepEqual(sortedNFATransitions(reparsed), expected) {
+		t.Errorf("Несовпадение Transitions: \nожидалось %v, \nполучено  %v", expected, reparsed.Transitions)
+	}
+	if !reparsed.AcceptingStates["A"] || !reparsed.AcceptingStates["H"] {
+		t.Errorf("Ожидались принимающие состояния A и H, получено %v", reparsed.AcceptingStates)
+	}
+}
+
+func TestNFAWriterKeepsLiteralEpsilonSymbols(t *testing.T) {
+	nfa, err := parser.ParseGrammarToNFA("<S> -> \"eps\" <S> | \"λ\" <S> | \"ε\" | eps\n")
+	if err != nil {
+		t.Fatalf("Ошибка парсинга грамматики: %v", err)
+	}
+	expected := determinizer.NewDeterminizer(nfa).Run()
+
+	for _, options := range []writer.Options{{}, {MergeParallelEdges: true}} {
+		reparsed := writeAndParseNFA(t, nfa, options)
+		if !reflect.DeepEqual(reparsed.Alphabet, []string{"eps", "ε", "λ"}) {
+			t.Errorf("Несовпадение Alphabet: ожидалось [eps ε λ], получено %q", reparsed.Alphabet)
+		}
+		assertDFAEqual(t, expected, determinizer.NewDeterminizer(reparsed).Run())
+	}
+	if !acceptsDFA(expected, []string{"eps", "λ", "ε"}) || !acceptsDFA(expected, []string{"eps"}) || acceptsDFA(expected, []string{"ε", "eps"}) {
+		t.Errorf("Символы eps, λ и ε должны оставаться обычными терминалами")
+	}
+}
+
+func writeAndParseNFA(t *testing.T, nfa *model.NFA, options writer.Options) *model.NFA {
+	t.Helper()
+	outputFile := filepath.Join(t.TempDir(), "nfa.dot")
+	if err := writer.NewWriterWithOptions(options).WriteNFAToFile(nfa, outputFile); err != nil {
+		t.Fatalf("Не удалось записать НКА: %v", err)
+	}
+	data, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("Не удалось прочитать НКА: %v", err)
+	}
+	reparsed, err := parser.ParseNFA(string(data))
+	if err != nil {
+		t.Fatalf("Не удалось распарсить записанный НКА: %v\n%s", err, data)
+	}
+	return reparsed
+}
+
+func sortedNFATransitions(nfa *model.NFA) map[string]map[string][]string {
+	result := make(map[string]map[string][]string)
+	for from, transitions := range nfa.Transitions {
+		for symbol, targets := range transitions {
+			if len(targets) == 0 {
+				continue
+			}
+			if _, ok := result[from]; !ok {
+				result[from] = make(map[string][]string)
+			}
+			sorted := append([]string(nil), targets...)
+			sort.Strings(sorted)
+			result[from][symbol] = sorted
+		}
+	}
+	return result
+}
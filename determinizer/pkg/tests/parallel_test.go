@@ -0,0 +1,28 @@
+package tests
+
+import (
+	"path/filepath"
+	"testing"
+
+	"determinizer/pkg/determinizer"
+)
+
+func TestRunParallelMatchesSequential(t *testing.T) {
+	testCases := []string{
+		"simple_nfa.dot",
+		"hard_nfa.dot",
+		"merge_paths_nfa.dot",
+		"overlapping_paths_nfa.dot",
+		"multi_start_nfa.dot",
+		"epsilon_aliases_nfa.dot",
+	}
+
+	for _, inputFile := range testCases {
+		for _, workers := range []int{0, 2, 8} {
+			nfa := parseNFAFile(t, filepath.Join(testNFADataDir, inputFile))
+			expectedDFA := determinizer.NewDeterminizer(nfa).Run()
+			actualDFA := determinizer.NewDeterminizer(nfa).RunParallel(workers)
+			assertDFAEqual(t, expectedDFA, actualDFA)
+		}
+	}
+}
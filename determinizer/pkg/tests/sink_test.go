@@ -0,0 +1,42 @@
+package tests
+
+import (
+	"path/filepath"
+	"testing"
+
+	"determinizer/pkg/determinizer"
+	"fsm/pkg/automaton"
+)
+
+func TestCompleteDeterminization(t *testing.T) {
+	nfa := parseNFAFile(t, filepath.Join(testNFADataDir, "merge_paths_nfa.dot"))
+	partial := determinizer.NewDeterminizer(nfa).Run()
+	complete := determinizer.NewDeterminizerWithOptions(nfa, determinizer.Options{Complete: true}).Run()
+
+	if !automaton.IsComplete(complete) {
+		t.Fatalf("ДКА с опцией Complete должен быть полным")
+	}
+	if len(complete.States) != len(partial.States)+1 {
+		t.Errorf("Несовпадение числа состояний: ожидалось %d, получено %d", len(partial.States)+1, len(complete.States))
+	}
+	if sinks := automaton.SinkStates(complete); len(sinks) != 1 || sinks[0] != automaton.SinkState {
+		t.Errorf("Ожидалось одно тупиковое состояние %q, получено %v", automaton.SinkState, sinks)
+	}
+	for _, word := range allWords(complete.Alphabet, 5) {
+		if acceptsDFA(partial, word) != acceptsDFA(complete, word) {
+			t.Errorf("Полный и частичный ДКА по-разному обрабатывают слово %v", word)
+		}
+	}
+
+	assertDFAEqual(t, partial, automaton.StripSinks(complete))
+	assertDFAEqual(t, complete, automaton.Complete(partial))
+}
+
+func TestCompleteParallelMatchesSequential(t *testing.T) {
+	nfa := parseNFAFile(t, filepath.Join(testNFADataDir, "merge_paths_nfa.dot"))
+	options := determinizer.Options{Naming: determinizer.NamingSequential, Complete: true}
+	sequential := determinizer.NewDeterminizerWithOptions(nfa, options).Run()
+	parallel := determinizer.NewDeterminizerWithOptions(nfa, options).RunParallel(4)
+
+	assertDFAEqual(t, sequential, parallel)
+}
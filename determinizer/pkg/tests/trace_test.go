@@ -0,0 +1,110 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"determinizer/pkg/determinizer"
+	"determinizer/pkg/model"
+	"determinizer/pkg/writer"
+)
+
+func TestTraceSteps(t *testing.T) {
+	nfa := parseNFAFile(t, filepath.Join(testNFADataDir, "merge_paths_nfa.dot"))
+	options := determinizer.Options{Naming: determinizer.NamingSequential, Trace: true}
+	d := determinizer.NewDeterminizerWithOptions(nfa, options)
+	d.Run()
+	trace := d.Trace()
+
+	if len(trace) != 17 {
+		t.Fatalf("Несовпадение числа шагов: ожидалось 17, получено %d", len(trace))
+	}
+
+	expected := map[int]model.TraceStep{
+		0:  {Move: []string{"q0"}, Closure: []string{"q0"}, To: "D0", IsNew: true},
+		1:  {From: "D0", FromSubset: []string{"q0"}, Symbol: "a", Move: []string{"q1"}, Closure: []string{"q1", "q2", "q3"}, To: "D1", IsNew: true},
+		2:  {From: "D0", FromSubset: []string{"q0"}, Symbol: "b", Move: []string{}},
+		6:  {From: "D1", FromSubset: []string{"q1", "q2", "q3"}, Symbol: "b", Move: []string{"q4"}, Closure: []string{"q4"}, To: "D2", IsNew: true},
+		7:  {From: "D1", FromSubset: []string{"q1", "q2", "q3"}, Symbol: "c", Move: []string{"q4"}, Closure: []string{"q4"}, To: "D2"},
+		12: {From: "D2", FromSubset: []string{"q4"}, Symbol: "d", Move: []string{"q5"}, Closure: []string{"q5"}, To: "D3", IsNew: true},
+	}
+	for index, step := range expected {
+		if !reflect.DeepEqual(trace[index], step) {
+			t.Errorf("Несовпадение шага %d: ожидалось %+v, получено %+v", index, step, trace[index])
+		}
+	}
+}
+
+func TestTraceDisabled(t *testing.T) {
+	nfa := parseNFAFile(t, filepath.Join(testNFADataDir, "merge_paths_nfa.dot"))
+	d := determinizer.NewDeterminizer(nfa)
+	d.Run()
+
+	if len(d.Trace()) != 0 {
+		t.Errorf("Трассировка без опции Trace должна быть пустой, получено %d шагов", len(d.Trace()))
+	}
+}
+
+func TestTraceParallelFallback(t *testing.T) {
+	nfa := parseNFAFile(t, filepath.Join(testNFADataDir, "merge_paths_nfa.dot"))
+	options := determinizer.Options{Naming: determinizer.NamingSequential, Trace: true}
+
+	sequential := determinizer.NewDeterminizerWithOptions(nfa, options)
+	sequential.Run()
+	parallel := determinizer.NewDeterminizerWithOptions(nfa, options)
+	parallel.RunParallel(4)
+
+	if !reflect.DeepEqual(sequential.Trace(), parallel.Trace()) {
+		t.Errorf("Трассировки последовательного и параллельного построения различаются")
+	}
+}
+
+func TestTraceWriters(t *testing.T) {
+	nfa := parseNFAFile(t, filepath.Join(testNFADataDir, "merge_paths_nfa.dot"))
+	options := determinizer.Options{Naming: determinizer.NamingSequential, Trace: true}
+	d := determinizer.NewDeterminizerWithOptions(nfa, options)
+	d.Run()
+	trace := d.Trace()
+
+	testCases := []struct {
+		name     string
+		write    func([]model.TraceStep, string) error
+		expected []string
+	}{
+		{"markdown", writer.WriteTraceMarkdown, []string{
+			"| Состояние | Подмножество | Символ | move | ε-замыкание | Переход | Новое |",
+			"|  |  |  | {q0} | {q0} | D0 | да |",
+			"| D0 | {q0} | a | {q1} | {q1, q2, q3} | D1 | да |",
+			"| D0 | {q0} | b | ∅ | ∅ |  |  |",
+		}},
+		{"csv", writer.WriteTraceCSV, []string{
+			"from,subset,symbol,move,closure,to,new",
+			"D1,\"{q1, q2, q3}\",c,{q4},{q4},D2,false",
+		}},
+		{"html", writer.WriteTraceHTML, []string{
+			"<th>ε-замыкание</th>",
+			"<tr><td>D2</td><td>{q4}</td><td>d</td><td>{q5}</td><td>{q5}</td><td>D3</td><td>да</td></tr>",
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			outputFile := filepath.Join(t.TempDir(), "trace")
+			if err := tc.write(trace, outputFile); err != nil {
+				t.Fatalf("Не удалось записать трассировку: %v", err)
+			}
+			data, err := os.ReadFile(outputFile)
+			if err != nil {
+				t.Fatalf("Не удалось прочитать трассировку: %v", err)
+			}
+			for _, line := range tc.expected {
+				if !strings.Contains(string(data), line) {
+					t.Errorf("Трассировка не содержит %q:\n%s", line, data)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package tests
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"determinizer/pkg/determinizer"
+	"determinizer/pkg/transform"
+)
+
+func TestExpandWords(t *testing.T) {
+	nfa := parseNFAFile(t, filepath.Join(testNFADataDir, "word_labels_nfa.dot"))
+
+	expanded := transform.ExpandWords(nfa)
+	expectedStates := []string{"W0", "W1", "q0", "q1"}
+	if !reflect.DeepEqual(expanded.States, expectedStates) {
+		t.Errorf("Несовпадение States: ожидалось %v, получено %v", expectedStates, expanded.States)
+	}
+	expectedAlphabet := []string{determinizer.EpsilonSymbol, "a", "b", "c"}
+	if !reflect.DeepEqual(expanded.Alphabet, expectedAlphabet) {
+		t.Errorf("Несовпадение Alphabet: ожидалось %v, получено %v", expectedAlphabet, expanded.Alphabet)
+	}
+	if len(nfa.States) != 2 {
+		t.Errorf("Исходный НКА не должен изменяться, получено состояний: %d", len(nfa.States))
+	}
+
+	actualDFA := determinizer.NewDeterminizer(expanded).Run()
+	expectedDFA := parseDFAFile(t, filepath.Join(testNFADataDir, "word_labels_dfa_expected.dot"))
+	assertDFAEqual(t, expectedDFA, actualDFA)
+}
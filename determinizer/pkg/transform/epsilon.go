@@ -0,0 +1,74 @@
+package transform
+
+import (
+	"sort"
+
+	"determinizer/pkg/model"
+)
+
+func RemoveEpsilon(nfa *model.NFA) *model.NFA {
+	result := model.NewNFA()
+	result.States = append(result.States, nfa.States...)
+	result.StartStates = append(result.StartStates, nfa.StartStates...)
+
+	for _, symbol := range nfa.Alphabet {
+		if symbol != model.Epsilon {
+			result.Alphabet = append(result.Alphabet, symbol)
+		}
+	}
+	sort.Strings(result.Alphabet)
+
+	for _, state := range nfa.States {
+		closure := epsilonClosure(state, nfa)
+		for _, member := range closure {
+			if nfa.AcceptingStates[member] {
+				result.AcceptingStates[state] = true
+			}
+			for symbol, destStates := range nfa.Transitions[member] {
+				if symbol == model.Epsilon {
+					continue
+				}
+				for _, dest := range destStates {
+					addUniqueTransition(result, state, symbol, dest)
+				}
+			}
+		}
+	}
+
+	for _, transitions := range result.Transitions {
+		for _, destStates := range transitions {
+			sort.Strings(destStates)
+		}
+	}
+	return result
+}
+
+func epsilonClosure(state string, nfa *model.NFA) []string {
+	visited := map[string]bool{state: true}
+	closure := []string{state}
+	stack := []string{state}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, dest := range nfa.Transitions[current][model.Epsilon] {
+			if !visited[dest] {
+				visited[dest] = true
+				closure = append(closure, dest)
+				stack = append(stack, dest)
+			}
+		}
+	}
+	return closure
+}
+
+func addUniqueTransition(nfa *model.NFA, from, symbol, to string) {
+	if _, ok := nfa.Transitions[from]; !ok {
+		nfa.Transitions[from] = make(map[string][]string)
+	}
+	for _, existing := range nfa.Transitions[from][symbol] {
+		if existing == to {
+			return
+		}
+	}
+	nfa.Transitions[from][symbol] = append(nfa.Transitions[from][symbol], to)
+}
@@ -0,0 +1,358 @@
+package transform
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"determinizer/pkg/model"
+)
+
+const (
+	grammarStartSymbol  = "S"
+	namedStatePrefix    = "N"
+	newStartNonTerminal = ""
+)
+
+var reservedNonTerminals = map[string]bool{"H": true, "F": true}
+
+type grammarBuilder struct {
+	nfa      *model.NFA
+	grammar  *model.Grammar
+	names    map[string]string
+	incoming map[string]bool
+	outgoing map[string]bool
+}
+
+func DFAToNFA(dfa *model.DFA) *model.NFA {
+	nfa := model.NewNFA()
+	nfa.States = append(nfa.States, dfa.States...)
+	nfa.Alphabet = append(nfa.Alphabet, dfa.Alphabet...)
+	nfa.StartStates = []string{dfa.StartState}
+	for state, accepting := range dfa.AcceptingStates {
+		if accepting {
+			nfa.AcceptingStates[state] = true
+		}
+	}
+	for from, transitions := range dfa.Transitions {
+		nfa.Transitions[from] = make(map[string][]string, len(transitions))
+		for symbol, to := range transitions {
+			nfa.Transitions[from][symbol] = []string{to}
+		}
+	}
+	return nfa
+}
+
+func RightLinearGrammar(nfa *model.NFA) (*model.Grammar, error) {
+	b, err := newGrammarBuilder(nfa, model.RightLinear)
+	if err != nil {
+		return nil, err
+	}
+
+	var start []string
+	for _, state := range b.nfa.StartStates {
+		if b.outgoing[state] || b.nfa.AcceptingStates[state] {
+			start = append(start, state)
+		}
+	}
+	if err = b.assignNames(start, b.outgoing); err != nil {
+		return nil, err
+	}
+
+	for _, from := range b.nfa.States {
+		if !b.outgoing[from] {
+			continue
+		}
+		b.forEachTransition(from, func(symbol, to string) {
+			if b.nfa.AcceptingStates[to] {
+				b.add(from, model.Production{Symbols: []model.GrammarSymbol{model.Terminal(symbol)}})
+			}
+			if b.outgoing[to] {
+				b.add(from, model.Production{Symbols: []model.GrammarSymbol{model.Terminal(symbol), model.NonTerminal(b.names[to])}})
+			}
+		})
+	}
+
+	acceptsEmpty := false
+	for _, state := range start {
+		acceptsEmpty = acceptsEmpty || b.nfa.AcceptingStates[state]
+	}
+	return b.finish(start, acceptsEmpty), nil
+}
+
+func LeftLinearGrammar(nfa *model.NFA) (*model.Grammar, error) {
+	b, err := newGrammarBuilder(nfa, model.LeftLinear)
+	if err != nil {
+		return nil, err
+	}
+
+	isStart := make(map[string]bool)
+	for _, state := range b.nfa.StartStates {
+		isStart[state] = true
+	}
+	var accepting []string
+	for _, state := range b.nfa.States {
+		if b.nfa.AcceptingStates[state] && (b.incoming[state] || isStart[state]) {
+			accepting = append(accepting, state)
+		}
+	}
+	if err = b.assignNames(accepting, b.incoming); err != nil {
+		return nil, err
+	}
+
+	for _, from := range b.nfa.States {
+		b.forEachTransition(from, func(symbol, to string) {
+			if isStart[from] {
+				b.add(to, model.Production{Symbols: []model.GrammarSymbol{model.Terminal(symbol)}})
+			}
+			if b.incoming[from] {
+				b.add(to, model.Production{Symbols: []model.GrammarSymbol{model.NonTerminal(b.names[from]), model.Terminal(symbol)}})
+			}
+		})
+	}
+
+	acceptsEmpty := false
+	for _, state := range accepting {
+		acceptsEmpty = acceptsEmpty || isStart[state]
+	}
+	return b.finish(accepting, acceptsEmpty), nil
+}
+
+func newGrammarBuilder(nfa *model.NFA, kind model.GrammarKind) (*grammarBuilder, error) {
+	b := &grammarBuilder{
+		nfa:      trimNFA(RemoveEpsilon(nfa)),
+		grammar:  model.NewGrammar(kind),
+		names:    make(map[string]string),
+		incoming: make(map[string]bool),
+		outgoing: make(map[string]bool),
+	}
+	for _, from := range b.nfa.States {
+		b.forEachTransition(from, func(_, to string) {
+			b.outgoing[from] = true
+			b.incoming[to] = true
+		})
+	}
+	return b, nil
+}
+
+func (b *grammarBuilder) assignNames(ends []string, hasProductions map[string]bool) error {
+	if len(ends) == 0 {
+		return errors.New("automaton accepts the empty language")
+	}
+
+	var available []string
+	terminals := make(map[string]bool)
+	for _, symbol := range b.nfa.Alphabet {
+		terminals[symbol] = true
+	}
+	for letter := 'A'; letter <= 'Z'; letter++ {
+		name := string(letter)
+		if !reservedNonTerminals[name] && !terminals[name] && name != grammarStartSymbol {
+			available = append(available, name)
+		}
+	}
+
+	var states []string
+	if len(ends) == 1 {
+		states = append(states, ends[0])
+	} else {
+		states = append(states, newStartNonTerminal)
+	}
+	for _, state := range b.nfa.States {
+		if hasProductions[state] && state != states[0] {
+			states = append(states, state)
+		}
+	}
+
+	if !terminals[grammarStartSymbol] {
+		available = append([]string{grammarStartSymbol}, available...)
+	}
+	if len(states) > len(available) || !singleRuneSymbols(b.nfa.Alphabet) {
+		available = namedNonTerminals(states)
+	}
+	for i, state := range states {
+		b.names[state] = available[i]
+		b.grammar.NonTerminals = append(b.grammar.NonTerminals, available[i])
+	}
+	b.grammar.Start = b.grammar.NonTerminals[0]
+	return nil
+}
+
+func namedNonTerminals(states []string) []string {
+	taken := make(map[string]bool)
+	names := make([]string, len(states))
+	for i, state := range states {
+		base := state
+		if state == newStartNonTerminal {
+			base = grammarStartSymbol
+		} else if !isPlainName(state) {
+			base = namedStatePrefix
+		}
+		name := base
+		for suffix := 1; taken[name]; suffix++ {
+			name = base + strconv.Itoa(suffix)
+		}
+		taken[name] = true
+		names[i] = name
+	}
+	return names
+}
+
+func isPlainName(name string) bool {
+	return name != "" && !strings.ContainsAny(name, " \t\n<>|\"'\\") && !strings.Contains(name, "->")
+}
+
+func singleRuneSymbols(symbols []string) bool {
+	for _, symbol := range symbols {
+		if utf8.RuneCountInString(symbol) != 1 {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *grammarBuilder) forEachTransition(from string, visit func(symbol, to string)) {
+	for _, symbol := range b.nfa.Alphabet {
+		for _, to := range b.nfa.Transitions[from][symbol] {
+			visit(symbol, to)
+		}
+	}
+}
+
+func (b *grammarBuilder) add(state string, production model.Production) {
+	name := b.names[state]
+	for _, existing := range b.grammar.Productions[name] {
+		if sameProduction(existing, production) {
+			return
+		}
+	}
+	b.grammar.Productions[name] = append(b.grammar.Productions[name], production)
+}
+
+func (b *grammarBuilder) finish(ends []string, acceptsEmpty bool) *model.Grammar {
+	start := ends[0]
+	if len(ends) > 1 {
+		start = newStartNonTerminal
+		for _, state := range ends {
+			name, ok := b.names[state]
+			if !ok {
+				continue
+			}
+			for _, production := range b.grammar.Productions[name] {
+				b.add(start, production)
+			}
+		}
+	}
+	if acceptsEmpty {
+		b.add(start, model.Production{})
+	}
+	b.removeUnreachable()
+	return b.grammar
+}
+
+func (b *grammarBuilder) removeUnreachable() {
+	reachable := map[string]bool{b.grammar.Start: true}
+	stack := []string{b.grammar.Start}
+	for len(stack) > 0 {
+		name := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, production := range b.grammar.Productions[name] {
+			for _, symbol := range production.Symbols {
+				if symbol.NonTerminal && !reachable[symbol.Value] {
+					reachable[symbol.Value] = true
+					stack = append(stack, symbol.Value)
+				}
+			}
+		}
+	}
+
+	var nonTerminals []string
+	for _, name := range b.grammar.NonTerminals {
+		if reachable[name] {
+			nonTerminals = append(nonTerminals, name)
+		} else {
+			delete(b.grammar.Productions, name)
+		}
+	}
+	b.grammar.NonTerminals = nonTerminals
+}
+
+func sameProduction(a, b model.Production) bool {
+	if len(a.Symbols) != len(b.Symbols) {
+		return false
+	}
+	for i := range a.Symbols {
+		if a.Symbols[i] != b.Symbols[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func trimNFA(nfa *model.NFA) *model.NFA {
+	reachable := make(map[string]bool)
+	stack := append([]string(nil), nfa.StartStates...)
+	for _, state := range stack {
+		reachable[state] = true
+	}
+	reverse := make(map[string][]string)
+	for len(stack) > 0 {
+		state := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, targets := range nfa.Transitions[state] {
+			for _, to := range targets {
+				reverse[to] = append(reverse[to], state)
+				if !reachable[to] {
+					reachable[to] = true
+					stack = append(stack, to)
+				}
+			}
+		}
+	}
+
+	live := make(map[string]bool)
+	for state := range reachable {
+		if nfa.AcceptingStates[state] {
+			live[state] = true
+			stack = append(stack, state)
+		}
+	}
+	for len(stack) > 0 {
+		state := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, from := range reverse[state] {
+			if !live[from] {
+				live[from] = true
+				stack = append(stack, from)
+			}
+		}
+	}
+
+	result := model.NewNFA()
+	result.Alphabet = append(result.Alphabet, nfa.Alphabet...)
+	for _, state := range nfa.StartStates {
+		if live[state] {
+			result.StartStates = append(result.StartStates, state)
+		}
+	}
+	for _, state := range nfa.States {
+		if !live[state] {
+			continue
+		}
+		result.States = append(result.States, state)
+		if nfa.AcceptingStates[state] {
+			result.AcceptingStates[state] = true
+		}
+		for symbol, targets := range nfa.Transitions[state] {
+			for _, to := range targets {
+				if live[to] {
+					addUniqueTransition(result, state, symbol, to)
+				}
+			}
+		}
+	}
+	sort.Strings(result.States)
+	return result
+}
@@ -0,0 +1,104 @@
+package transform
+
+import (
+	"fmt"
+	"sort"
+	"unicode/utf8"
+
+	"determinizer/pkg/determinizer"
+	"determinizer/pkg/model"
+)
+
+const wordStatePrefix = "W"
+
+type wordExpansion struct {
+	result       *model.NFA
+	usedStates   map[string]bool
+	alphabetSet  map[string]bool
+	stateCounter int
+}
+
+func ExpandWords(nfa *model.NFA) *model.NFA {
+	e := &wordExpansion{
+		result:      model.NewNFA(),
+		usedStates:  make(map[string]bool),
+		alphabetSet: make(map[string]bool),
+	}
+	for _, state := range nfa.States {
+		e.usedStates[state] = true
+	}
+	e.result.States = append(e.result.States, nfa.States...)
+	e.result.StartStates = append(e.result.StartStates, nfa.StartStates...)
+	for state, accepting := range nfa.AcceptingStates {
+		e.result.AcceptingStates[state] = accepting
+	}
+
+	sortedStates := make([]string, 0, len(nfa.Transitions))
+	for from := range nfa.Transitions {
+		sortedStates = append(sortedStates, from)
+	}
+	sort.Strings(sortedStates)
+
+	for _, from := range sortedStates {
+		transitions := nfa.Transitions[from]
+		sortedSymbols := make([]string, 0, len(transitions))
+		for symbol := range transitions {
+			sortedSymbols = append(sortedSymbols, symbol)
+		}
+		sort.Strings(sortedSymbols)
+
+		for _, symbol := range sortedSymbols {
+			for _, to := range transitions[symbol] {
+				if isWord(symbol) {
+					e.addChain(from, symbol, to)
+				} else {
+					e.addTransition(from, symbol, to)
+				}
+			}
+		}
+	}
+
+	sort.Strings(e.result.States)
+	for symbol := range e.alphabetSet {
+		e.result.Alphabet = append(e.result.Alphabet, symbol)
+	}
+	sort.Strings(e.result.Alphabet)
+	return e.result
+}
+
+func isWord(symbol string) bool {
+	return symbol != determinizer.EpsilonSymbol && utf8.RuneCountInString(symbol) > 1
+}
+
+func (e *wordExpansion) addChain(from, word, to string) {
+	runes := []rune(word)
+	current := from
+	for i, r := range runes {
+		next := to
+		if i < len(runes)-1 {
+			next = e.newState()
+		}
+		e.addTransition(current, string(r), next)
+		current = next
+	}
+}
+
+func (e *wordExpansion) addTransition(from, symbol, to string) {
+	if _, ok := e.result.Transitions[from]; !ok {
+		e.result.Transitions[from] = make(map[string][]string)
+	}
+	e.result.Transitions[from][symbol] = append(e.result.Transitions[from][symbol], to)
+	e.alphabetSet[symbol] = true
+}
+
+func (e *wordExpansion) newState() string {
+	name := fmt.Sprintf("%s%d", wordStatePrefix, e.stateCounter)
+	for e.usedStates[name] {
+		e.stateCounter++
+		name = fmt.Sprintf("%s%d", wordStatePrefix, e.stateCounter)
+	}
+	e.stateCounter++
+	e.usedStates[name] = true
+	e.result.States = append(e.result.States, name)
+	return name
+}
@@ -0,0 +1,148 @@
+package writer
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"determinizer/pkg/model"
+	"dot/pkg/dot"
+)
+
+const (
+	grammarRule      = "%s -> %s\n"
+	grammarSeparator = " | "
+	grammarSpecials  = "<>|\"'\\"
+)
+
+type grammarFormatter struct {
+	grammar      *model.Grammar
+	nonTerminals map[string]bool
+	compact      bool
+}
+
+func WriteGrammarToFile(grammar *model.Grammar, filePath string) error {
+	return os.WriteFile(filePath, []byte(FormatGrammar(grammar)), 0644)
+}
+
+func FormatGrammar(grammar *model.Grammar) string {
+	f := &grammarFormatter{grammar: grammar, nonTerminals: make(map[string]bool)}
+	for _, nonTerminal := range grammar.NonTerminals {
+		f.nonTerminals[nonTerminal] = true
+	}
+	f.compact = f.isCompact()
+
+	var builder strings.Builder
+	for _, nonTerminal := range grammar.NonTerminals {
+		var alternatives []string
+		hasEpsilon := false
+		for _, production := range grammar.Productions[nonTerminal] {
+			if len(production.Symbols) == 0 {
+				hasEpsilon = true
+				continue
+			}
+			alternatives = append(alternatives, f.formatProduction(production))
+		}
+		sort.Strings(alternatives)
+		if hasEpsilon {
+			alternatives = append(alternatives, dot.EpsilonWord)
+		}
+		builder.WriteString(fmt.Sprintf(grammarRule, f.formatNonTerminal(nonTerminal), strings.Join(alternatives, grammarSeparator)))
+	}
+	return builder.String()
+}
+
+func (f *grammarFormatter) isCompact() bool {
+	for nonTerminal := range f.nonTerminals {
+		if len(nonTerminal) != 1 || nonTerminal[0] < 'A' || nonTerminal[0] > 'Z' {
+			return false
+		}
+	}
+	for _, productions := range f.grammar.Productions {
+		for _, production := range productions {
+			if !f.isCompactProduction(production) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (f *grammarFormatter) isCompactProduction(production model.Production) bool {
+	if len(production.Symbols) > 2 {
+		return false
+	}
+	terminals := 0
+	for _, symbol := range production.Symbols {
+		if symbol.NonTerminal {
+			continue
+		}
+		terminals++
+		if !f.isCompactTerminal(symbol.Value) {
+			return false
+		}
+	}
+	return terminals == 1 || len(production.Symbols) == 0
+}
+
+func (f *grammarFormatter) isCompactTerminal(terminal string) bool {
+	r, size := utf8.DecodeRuneInString(terminal)
+	return size == len(terminal) && !unicode.IsSpace(r) && !strings.ContainsRune(grammarSpecials, r) &&
+		!f.nonTerminals[terminal] && !dot.IsEpsilon(terminal, dot.DefaultEpsilonAliases)
+}
+
+func (f *grammarFormatter) formatProduction(production model.Production) string {
+	parts := make([]string, len(production.Symbols))
+	for i, symbol := range production.Symbols {
+		switch {
+		case f.compact:
+			parts[i] = symbol.Value
+		case symbol.NonTerminal:
+			parts[i] = f.formatNonTerminal(symbol.Value)
+		default:
+			parts[i] = f.formatTerminal(symbol.Value)
+		}
+	}
+	if f.compact {
+		return strings.Join(parts, "")
+	}
+	return strings.Join(parts, " ")
+}
+
+func (f *grammarFormatter) formatNonTerminal(nonTerminal string) string {
+	if f.compact {
+		return nonTerminal
+	}
+	return "<" + nonTerminal + ">"
+}
+
+func (f *grammarFormatter) formatTerminal(terminal string) string {
+	if f.isPlainTerminal(terminal) {
+		return terminal
+	}
+	escaped := strings.ReplaceAll(terminal, "\\", "\\\\")
+	return "\"" + strings.ReplaceAll(escaped, "\"", "\\\"") + "\""
+}
+
+func (f *grammarFormatter) isPlainTerminal(terminal string) bool {
+	if strings.ContainsAny(terminal, grammarSpecials) || strings.Contains(terminal, "->") ||
+		f.nonTerminals[terminal] || dot.IsEpsilon(terminal, dot.DefaultEpsilonAliases) {
+		return false
+	}
+	for _, r := range terminal {
+		if unicode.IsSpace(r) {
+			return false
+		}
+	}
+	if utf8.RuneCountInString(terminal) == 2 {
+		for _, r := range terminal {
+			if f.nonTerminals[string(r)] {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,40 @@
+package writer
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"determinizer/pkg/model"
+)
+
+var mappingCSVHeader = []string{"state", "subset"}
+
+func WriteMappingCSV(mapping []model.StateMapping, filePath string) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	w := csv.NewWriter(file)
+	if err = w.Write(mappingCSVHeader); err != nil {
+		return err
+	}
+	for _, m := range mapping {
+		if err = w.Write([]string{m.State, strings.Join(m.Subset, " ")}); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func WriteMappingJSON(mapping []model.StateMapping, filePath string) error {
+	data, err := json.MarshalIndent(mapping, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, append(data, '\n'), 0644)
+}
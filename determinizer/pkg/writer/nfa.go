@@ -0,0 +1,62 @@
+package writer
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"determinizer/pkg/model"
+	"dot/pkg/dot"
+)
+
+func (w *Writer) WriteNFAToFile(nfa *model.NFA, filePath string) error {
+	dotString := w.generateNFADOTString(nfa)
+	return os.WriteFile(filePath, []byte(dotString), 0644)
+}
+
+func (w *Writer) generateNFADOTString(nfa *model.NFA) string {
+	w.builder.Reset()
+	w.writeHeader()
+	w.writeAcceptingStates(nfa.AcceptingStates)
+	w.writeAllNodes()
+	w.writeStartStates(nfa.StartStates...)
+	w.writeNFATransitions(nfa)
+	w.writeFooter()
+	return w.builder.String()
+}
+
+func (w *Writer) writeNFATransitions(nfa *model.NFA) {
+	sortedStates := make([]string, 0, len(nfa.Transitions))
+	for from := range nfa.Transitions {
+		sortedStates = append(sortedStates, from)
+	}
+	sort.Strings(sortedStates)
+
+	for _, from := range sortedStates {
+		transitions := nfa.Transitions[from]
+		if w.options.MergeParallelEdges {
+			symbolsByTarget := make(map[string][]string)
+			for symbol, targets := range transitions {
+				for _, to := range targets {
+					symbolsByTarget[to] = append(symbolsByTarget[to], symbol)
+				}
+			}
+			w.writeMergedEdges(from, symbolsByTarget)
+			continue
+		}
+
+		symbols := make([]string, 0, len(transitions))
+		for symbol := range transitions {
+			symbols = append(symbols, symbol)
+		}
+		sort.Strings(symbols)
+		for _, symbol := range symbols {
+			targets := append([]string(nil), transitions[symbol]...)
+			sort.Strings(targets)
+			for _, to := range targets {
+				line := fmt.Sprintf(transition, dot.QuoteID(from), dot.QuoteID(to), dot.EscapeLabel(w.formatSymbol(symbol)))
+				w.builder.WriteString(line)
+			}
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package writer
+
+import (
+	"encoding/csv"
+	"fmt"
+	"html"
+	"os"
+	"strconv"
+	"strings"
+
+	"determinizer/pkg/model"
+)
+
+const (
+	emptySet = "∅"
+
+	traceHTMLHeader = "<table>\n"
+	traceHTMLFooter = "</table>\n"
+	traceHTMLRow    = "  <tr>%s</tr>\n"
+)
+
+var (
+	traceCSVHeader   = []string{"from", "subset", "symbol", "move", "closure", "to", "new"}
+	traceTableHeader = []string{"Состояние", "Подмножество", "Символ", "move", "ε-замыкание", "Переход", "Новое"}
+)
+
+func WriteTraceMarkdown(trace []model.TraceStep, filePath string) error {
+	var builder strings.Builder
+	writeMarkdownRow(&builder, traceTableHeader)
+	separator := make([]string, len(traceTableHeader))
+	for i := range separator {
+		separator[i] = "---"
+	}
+	writeMarkdownRow(&builder, separator)
+	for _, step := range trace {
+		row := traceRow(step)
+		for i, cell := range row {
+			row[i] = strings.ReplaceAll(cell, "|", "\\|")
+		}
+		writeMarkdownRow(&builder, row)
+	}
+	return os.WriteFile(filePath, []byte(builder.String()), 0644)
+}
+
+func WriteTraceCSV(trace []model.TraceStep, filePath string) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	w := csv.NewWriter(file)
+	if err = w.Write(traceCSVHeader); err != nil {
+		return err
+	}
+	for _, step := range trace {
+		row := traceRow(step)
+		row[len(row)-1] = strconv.FormatBool(step.IsNew)
+		if err = w.Write(row); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func WriteTraceHTML(trace []model.TraceStep, filePath string) error {
+	var builder strings.Builder
+	builder.WriteString(traceHTMLHeader)
+	builder.WriteString(fmt.Sprintf(traceHTMLRow, htmlCells("th", traceTableHeader)))
+	for _, step := range trace {
+		builder.WriteString(fmt.Sprintf(traceHTMLRow, htmlCells("td", traceRow(step))))
+	}
+	builder.WriteString(traceHTMLFooter)
+	return os.WriteFile(filePath, []byte(builder.String()), 0644)
+}
+
+func traceRow(step model.TraceStep) []string {
+	isNew := ""
+	if step.IsNew {
+		isNew = "да"
+	}
+	subset := ""
+	if step.From != "" {
+		subset = formatSet(step.FromSubset)
+	}
+	return []string{
+		step.From,
+		subset,
+		step.Symbol,
+		formatSet(step.Move),
+		formatSet(step.Closure),
+		step.To,
+		isNew,
+	}
+}
+
+func formatSet(states []string) string {
+	if len(states) == 0 {
+		return emptySet
+	}
+	return "{" + strings.Join(states, ", ") + "}"
+}
+
+func writeMarkdownRow(builder *strings.Builder, cells []string) {
+	builder.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+}
+
+func htmlCells(tag string, cells []string) string {
+	var builder strings.Builder
+	for _, cell := range cells {
+		builder.WriteString(fmt.Sprintf("<%s>%s</%s>", tag, html.EscapeString(cell), tag))
+	}
+	return builder.String()
+}
@@ -3,10 +3,13 @@ package writer
 import (
 	"fmt"
 	"os"
+	"slices"
 	"sort"
 	"strings"
 
 	"determinizer/pkg/model"
+	"dot/pkg/dot"
+	"fsm/pkg/automaton"
 )
 
 const (
@@ -18,27 +21,45 @@ const (
 	startState           = "\tstart [shape=point, style=invis];\n"
 	startStateTransition = "\tstart -> %s;\n"
 	transition           = "\t%s -> %s [label = \"%s\"];\n"
+	stateMappingComment  = "\t// %s = {%s}\n"
+	labelSeparator       = ","
 )
 
+type Options struct {
+	MergeParallelEdges bool
+	CompressRanges     bool
+	CompleteWithSink   bool
+	StripSinkStates    bool
+	Epsilon            string
+	StateMapping       []model.StateMapping
+}
+
 type Writer struct {
 	builder strings.Builder
+	options Options
 }
 
 func NewWriter() *Writer {
 	return &Writer{}
 }
 
+func NewWriterWithOptions(options Options) *Writer {
+	return &Writer{options: options}
+}
+
 func (w *Writer) WriteToFile(dfa *model.DFA, filePath string) error {
 	dotString := w.generateDOTString(dfa)
 	return os.WriteFile(filePath, []byte(dotString), 0644)
 }
 
 func (w *Writer) generateDOTString(dfa *model.DFA) string {
+	dfa = automaton.ApplySinkOptions(dfa, w.options.CompleteWithSink, w.options.StripSinkStates)
 	w.builder.Reset()
 	w.writeHeader()
-	w.writeAcceptingStates(dfa)
+	w.writeStateMapping()
+	w.writeAcceptingStates(dfa.AcceptingStates)
 	w.writeAllNodes()
-	w.writeStartState(dfa)
+	w.writeStartStates(dfa.StartState)
 	w.writeTransitions(dfa)
 	w.writeFooter()
 	return w.builder.String()
@@ -49,10 +70,19 @@ func (w *Writer) writeHeader() {
 	w.builder.WriteString(digraphDirection)
 }
 
-func (w *Writer) writeAcceptingStates(dfa *model.DFA) {
+func (w *Writer) writeStateMapping() {
+	for _, m := range w.options.StateMapping {
+		line := fmt.Sprintf(stateMappingComment, m.State, strings.Join(m.Subset, ", "))
+		w.builder.WriteString(line)
+	}
+}
+
+func (w *Writer) writeAcceptingStates(states map[string]bool) {
 	var accepting []string
-	for state := range dfa.AcceptingStates {
-		accepting = append(accepting, state)
+	for state, isAccepting := range states {
+		if isAccepting {
+			accepting = append(accepting, dot.QuoteID(state))
+		}
 	}
 	if len(accepting) > 0 {
 		sort.Strings(accepting)
@@ -65,10 +95,12 @@ func (w *Writer) writeAllNodes() {
 	w.builder.WriteString(nodes)
 }
 
-func (w *Writer) writeStartState(dfa *model.DFA) {
+func (w *Writer) writeStartStates(states ...string) {
 	w.builder.WriteString(startState)
-	line := fmt.Sprintf(startStateTransition, dfa.StartState)
-	w.builder.WriteString(line)
+	for _, state := range states {
+		line := fmt.Sprintf(startStateTransition, dot.QuoteID(state))
+		w.builder.WriteString(line)
+	}
 }
 
 func (w *Writer) writeTransitions(dfa *model.DFA) {
@@ -80,13 +112,53 @@ func (w *Writer) writeTransitions(dfa *model.DFA) {
 
 	for _, from := range sortedStates {
 		transitions := dfa.Transitions[from]
+		if w.options.MergeParallelEdges {
+			w.writeMergedEdges(from, dot.GroupByTarget(transitions))
+			continue
+		}
 		for symbol, to := range transitions {
-			line := fmt.Sprintf(transition, from, to, symbol)
+			line := fmt.Sprintf(transition, dot.QuoteID(from), dot.QuoteID(to), dot.EscapeLabel(w.formatSymbol(symbol)))
 			w.builder.WriteString(line)
 		}
 	}
 }
 
+func (w *Writer) writeMergedEdges(from string, symbolsByTarget map[string][]string) {
+	epsilonTargets := make(map[string]bool)
+	for to, symbols := range symbolsByTarget {
+		if i := slices.Index(symbols, model.Epsilon); i >= 0 {
+			epsilonTargets[to] = true
+			symbolsByTarget[to] = slices.Delete(symbols, i, i+1)
+		}
+	}
+
+	for _, edge := range dot.MergeEdges(symbolsByTarget, w.options.CompressRanges) {
+		label := edge.Label
+		switch {
+		case epsilonTargets[edge.To] && label == "":
+			label = w.epsilonLabel()
+		case epsilonTargets[edge.To]:
+			label = w.epsilonLabel() + labelSeparator + label
+		}
+		line := fmt.Sprintf(transition, dot.QuoteID(from), dot.QuoteID(edge.To), dot.EscapeLabel(label))
+		w.builder.WriteString(line)
+	}
+}
+
+func (w *Writer) formatSymbol(symbol string) string {
+	if symbol == model.Epsilon {
+		return w.epsilonLabel()
+	}
+	return dot.EscapeSymbol(symbol)
+}
+
+func (w *Writer) epsilonLabel() string {
+	if w.options.Epsilon != "" {
+		return w.options.Epsilon
+	}
+	return dot.EpsilonWord
+}
+
 func (w *Writer) writeFooter() {
 	w.builder.WriteString(digraphFooter)
 }
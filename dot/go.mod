@@ -0,0 +1,3 @@
+module dot
+
+go 1.25
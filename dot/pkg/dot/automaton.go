@@ -0,0 +1,99 @@
+package dot
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	LabelAttr     = "label"
+	ShapeAttr     = "shape"
+	StartAttr     = "start"
+	StartNodeName = "start"
+
+	acceptingShape = "doublecircle"
+)
+
+var startMarkerShapes = map[string]bool{
+	"point":     true,
+	"none":      true,
+	"plaintext": true,
+	"plain":     true,
+}
+
+type Transition struct {
+	From  string
+	To    string
+	Label string
+	Pos   Position
+}
+
+type Automaton struct {
+	States      []string
+	StartStates []string
+	Accepting   map[string]bool
+	Transitions []Transition
+}
+
+func ParseAutomaton(input string) (*Automaton, error) {
+	graph, err := Parse(input)
+	if err != nil {
+		return nil, err
+	}
+	return ExtractAutomaton(graph)
+}
+
+func ExtractAutomaton(graph *Graph) (*Automaton, error) {
+	a := &Automaton{Accepting: make(map[string]bool)}
+
+	for _, node := range graph.Nodes {
+		if isStartMarker(node) {
+			continue
+		}
+		a.States = append(a.States, node.ID)
+		if strings.EqualFold(node.Attrs[ShapeAttr], acceptingShape) {
+			a.Accepting[node.ID] = true
+		}
+		if isTrue(node.Attrs[StartAttr]) {
+			a.addStartState(node.ID)
+		}
+	}
+
+	for _, edge := range graph.Edges {
+		from, _ := graph.Node(edge.From)
+		to, _ := graph.Node(edge.To)
+		if isStartMarker(to) {
+			return nil, &Error{Pos: edge.Pos, Message: fmt.Sprintf("edge %s -> %s leads into start marker", edge.From, edge.To)}
+		}
+		if isStartMarker(from) {
+			a.addStartState(edge.To)
+			continue
+		}
+
+		label, ok := edge.Attrs[LabelAttr]
+		if !ok {
+			return nil, &Error{Pos: edge.Pos, Message: fmt.Sprintf("edge %s -> %s has no label", edge.From, edge.To)}
+		}
+		a.Transitions = append(a.Transitions, Transition{From: edge.From, To: edge.To, Label: label, Pos: edge.Pos})
+	}
+	return a, nil
+}
+
+func isStartMarker(node *Node) bool {
+	return node.ID == StartNodeName || startMarkerShapes[strings.ToLower(node.Attrs[ShapeAttr])]
+}
+
+func (a *Automaton) addStartState(state string) {
+	if !slices.Contains(a.StartStates, state) {
+		a.StartStates = append(a.StartStates, state)
+	}
+}
+
+func isTrue(value string) bool {
+	switch strings.ToLower(value) {
+	case "true", "yes", "1":
+		return true
+	}
+	return false
+}
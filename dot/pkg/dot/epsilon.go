@@ -0,0 +1,16 @@
+package dot
+
+import "slices"
+
+const (
+	EpsilonWord   = "eps"
+	EpsilonGreek  = "ε"
+	EpsilonLambda = "λ"
+	EpsilonEmpty  = ""
+)
+
+var DefaultEpsilonAliases = []string{EpsilonWord, EpsilonGreek, EpsilonLambda, EpsilonEmpty}
+
+func IsEpsilon(symbol string, aliases []string) bool {
+	return slices.Contains(aliases, symbol)
+}
@@ -0,0 +1,170 @@
+package dot
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	symbolSeparator      = ','
+	alternativeSeparator = '|'
+	rangeSeparator       = '-'
+	escapeCharacter      = '\\'
+
+	minRangeLength = 3
+)
+
+type LabelSymbol struct {
+	Value   string
+	Literal bool
+}
+
+func SplitLabel(label string) ([]string, error) {
+	labelSymbols, err := ParseLabel(label)
+	if err != nil {
+		return nil, err
+	}
+
+	var symbols []string
+	seen := make(map[string]bool)
+	for _, symbol := range labelSymbols {
+		if !seen[symbol.Value] {
+			seen[symbol.Value] = true
+			symbols = append(symbols, symbol.Value)
+		}
+	}
+	return symbols, nil
+}
+
+func ParseLabel(label string) ([]LabelSymbol, error) {
+	if label == "" {
+		return nil, errors.New("empty label")
+	}
+
+	var parts [][]labelRune
+	var current []labelRune
+	escaped := false
+	for _, r := range label {
+		switch {
+		case escaped:
+			current = append(current, labelRune{r: r, escaped: true})
+			escaped = false
+		case r == escapeCharacter:
+			escaped = true
+		case r == symbolSeparator || r == alternativeSeparator:
+			parts = append(parts, current)
+			current = nil
+		default:
+			current = append(current, labelRune{r: r})
+		}
+	}
+	if escaped {
+		return nil, fmt.Errorf("dangling escape in label %q", label)
+	}
+	parts = append(parts, current)
+
+	var symbols []LabelSymbol
+	seen := make(map[LabelSymbol]bool)
+	for _, part := range parts {
+		part = trimSpace(part)
+		if len(part) == 0 {
+			return nil, fmt.Errorf("empty symbol in label %q", label)
+		}
+
+		expanded, err := expandPart(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label %q: %w", label, err)
+		}
+		for _, symbol := range expanded {
+			if !seen[symbol] {
+				seen[symbol] = true
+				symbols = append(symbols, symbol)
+			}
+		}
+	}
+	return symbols, nil
+}
+
+func FormatLabel(symbols []string, compressRanges bool) string {
+	sorted := append([]string(nil), symbols...)
+	sort.Strings(sorted)
+
+	var parts []string
+	for i := 0; i < len(sorted); {
+		j := i + 1
+		if compressRanges {
+			for j < len(sorted) && isNextRune(sorted[j-1], sorted[j]) {
+				j++
+			}
+		}
+		if j-i >= minRangeLength {
+			parts = append(parts, EscapeSymbol(sorted[i])+string(rangeSeparator)+EscapeSymbol(sorted[j-1]))
+			i = j
+			continue
+		}
+		parts = append(parts, EscapeSymbol(sorted[i]))
+		i++
+	}
+	return strings.Join(parts, string(symbolSeparator))
+}
+
+func EscapeSymbol(symbol string) string {
+	var b strings.Builder
+	literal := IsEpsilon(symbol, DefaultEpsilonAliases)
+	for i, r := range symbol {
+		switch {
+		case r == symbolSeparator, r == alternativeSeparator, r == rangeSeparator, r == escapeCharacter:
+			b.WriteRune(escapeCharacter)
+		case literal && i == 0:
+			b.WriteRune(escapeCharacter)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+type labelRune struct {
+	r       rune
+	escaped bool
+}
+
+func trimSpace(part []labelRune) []labelRune {
+	for len(part) > 0 && !part[0].escaped && part[0].r == ' ' {
+		part = part[1:]
+	}
+	for len(part) > 0 && !part[len(part)-1].escaped && part[len(part)-1].r == ' ' {
+		part = part[:len(part)-1]
+	}
+	return part
+}
+
+func expandPart(part []labelRune) ([]LabelSymbol, error) {
+	if len(part) == 3 && part[1].r == rangeSeparator && !part[1].escaped {
+		lo, hi := part[0].r, part[2].r
+		if lo > hi {
+			return nil, fmt.Errorf("reversed range %c-%c", lo, hi)
+		}
+		symbols := make([]LabelSymbol, 0, hi-lo+1)
+		for r := lo; r <= hi; r++ {
+			symbols = append(symbols, LabelSymbol{Value: string(r), Literal: true})
+		}
+		return symbols, nil
+	}
+
+	var symbol strings.Builder
+	literal := false
+	for _, lr := range part {
+		symbol.WriteRune(lr.r)
+		literal = literal || lr.escaped
+	}
+	return []LabelSymbol{{Value: symbol.String(), Literal: literal}}, nil
+}
+
+func isNextRune(prev, next string) bool {
+	p, prevSize := utf8.DecodeRuneInString(prev)
+	n, nextSize := utf8.DecodeRuneInString(next)
+	return prevSize == len(prev) && nextSize == len(next) && p != utf8.RuneError && n == p+1
+}
@@ -0,0 +1,282 @@
+package dot
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenID
+	tokenQuoted
+	tokenHTML
+	tokenLBrace
+	tokenRBrace
+	tokenLBracket
+	tokenRBracket
+	tokenEqual
+	tokenSemicolon
+	tokenComma
+	tokenColon
+	tokenPlus
+	tokenArrow
+)
+
+type Position struct {
+	Line   int
+	Column int
+}
+
+func (p Position) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+type Error struct {
+	Pos     Position
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Pos.Line, e.Pos.Column, e.Message)
+}
+
+type token struct {
+	kind  tokenKind
+	text  string
+	pos   Position
+	isKey bool
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of input"
+	case tokenQuoted:
+		return fmt.Sprintf("%q", t.text)
+	case tokenHTML:
+		return "<" + t.text + ">"
+	default:
+		return fmt.Sprintf("'%s'", t.text)
+	}
+}
+
+type lexer struct {
+	input  string
+	offset int
+	pos    Position
+}
+
+func newLexer(input string) *lexer {
+	return &lexer{
+		input: input,
+		pos:   Position{Line: 1, Column: 1},
+	}
+}
+
+func (l *lexer) peekRune() rune {
+	if l.offset >= len(l.input) {
+		return utf8.RuneError
+	}
+	r, _ := utf8.DecodeRuneInString(l.input[l.offset:])
+	return r
+}
+
+func (l *lexer) hasPrefix(prefix string) bool {
+	return strings.HasPrefix(l.input[l.offset:], prefix)
+}
+
+func (l *lexer) atEOF() bool {
+	return l.offset >= len(l.input)
+}
+
+func (l *lexer) advance() rune {
+	r, size := utf8.DecodeRuneInString(l.input[l.offset:])
+	l.offset += size
+	if r == '\n' {
+		l.pos.Line++
+		l.pos.Column = 1
+	} else {
+		l.pos.Column++
+	}
+	return r
+}
+
+func (l *lexer) errorf(pos Position, format string, args ...any) error {
+	return &Error{Pos: pos, Message: fmt.Sprintf(format, args...)}
+}
+
+func (l *lexer) next() (token, error) {
+	if err := l.skipSpaceAndComments(); err != nil {
+		return token{}, err
+	}
+	start := l.pos
+	if l.atEOF() {
+		return token{kind: tokenEOF, pos: start}, nil
+	}
+
+	switch r := l.peekRune(); {
+	case l.hasPrefix("->"), l.hasPrefix("--"):
+		text := l.input[l.offset : l.offset+2]
+		l.advance()
+		l.advance()
+		return token{kind: tokenArrow, text: text, pos: start}, nil
+	case r == '"':
+		return l.lexQuoted(start)
+	case r == '<':
+		return l.lexHTML(start)
+	case r == '-' || r == '.' || unicode.IsDigit(r):
+		return l.lexNumeral(start)
+	case r == '_' || unicode.IsLetter(r) || r >= utf8.RuneSelf:
+		return l.lexIdentifier(start), nil
+	default:
+		kind, ok := punctuation[r]
+		if !ok {
+			return token{}, l.errorf(start, "unexpected character %q", r)
+		}
+		l.advance()
+		return token{kind: kind, text: string(r), pos: start}, nil
+	}
+}
+
+var punctuation = map[rune]tokenKind{
+	'{': tokenLBrace,
+	'}': tokenRBrace,
+	'[': tokenLBracket,
+	']': tokenRBracket,
+	'=': tokenEqual,
+	';': tokenSemicolon,
+	',': tokenComma,
+	':': tokenColon,
+	'+': tokenPlus,
+}
+
+func (l *lexer) skipSpaceAndComments() error {
+	lineStart := l.offset == 0 || l.input[l.offset-1] == '\n'
+	for !l.atEOF() {
+		r := l.peekRune()
+		switch {
+		case r == '\n':
+			l.advance()
+			lineStart = true
+		case unicode.IsSpace(r):
+			l.advance()
+		case r == '#' && lineStart:
+			l.skipLine()
+		case l.hasPrefix("//"):
+			l.skipLine()
+		case l.hasPrefix("/*"):
+			start := l.pos
+			end := strings.Index(l.input[l.offset+2:], "*/")
+			if end < 0 {
+				return l.errorf(start, "unterminated comment")
+			}
+			for target := l.offset + 2 + end + 2; l.offset < target; {
+				l.advance()
+			}
+			lineStart = false
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+func (l *lexer) skipLine() {
+	for !l.atEOF() && l.peekRune() != '\n' {
+		l.advance()
+	}
+}
+
+func (l *lexer) lexQuoted(start Position) (token, error) {
+	l.advance()
+	var text strings.Builder
+	for {
+		if l.atEOF() {
+			return token{}, l.errorf(start, "unterminated quoted string")
+		}
+		r := l.advance()
+		switch {
+		case r == '"':
+			return token{kind: tokenQuoted, text: text.String(), pos: start}, nil
+		case r == '\\' && (l.peekRune() == '"' || l.peekRune() == '\\'):
+			text.WriteRune(l.advance())
+		case r == '\\' && l.peekRune() == '\n':
+			l.advance()
+		case r == '\\' && l.hasPrefix("\r\n"):
+			l.advance()
+			l.advance()
+		default:
+			text.WriteRune(r)
+		}
+	}
+}
+
+func (l *lexer) lexHTML(start Position) (token, error) {
+	l.advance()
+	depth := 1
+	begin := l.offset
+	for {
+		if l.atEOF() {
+			return token{}, l.errorf(start, "unterminated HTML string")
+		}
+		end := l.offset
+		switch l.advance() {
+		case '<':
+			depth++
+		case '>':
+			depth--
+			if depth == 0 {
+				return token{kind: tokenHTML, text: l.input[begin:end], pos: start}, nil
+			}
+		}
+	}
+}
+
+func (l *lexer) lexNumeral(start Position) (token, error) {
+	begin := l.offset
+	if l.peekRune() == '-' {
+		l.advance()
+	}
+	digits, dots := 0, 0
+	for !l.atEOF() {
+		r := l.peekRune()
+		if r == '.' && dots == 0 {
+			dots++
+		} else if unicode.IsDigit(r) {
+			digits++
+		} else {
+			break
+		}
+		l.advance()
+	}
+	if digits == 0 {
+		return token{}, l.errorf(start, "invalid numeral %q", l.input[begin:l.offset])
+	}
+	return token{kind: tokenID, text: l.input[begin:l.offset], pos: start}, nil
+}
+
+func (l *lexer) lexIdentifier(start Position) token {
+	begin := l.offset
+	for !l.atEOF() {
+		r := l.peekRune()
+		if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) && r < utf8.RuneSelf {
+			break
+		}
+		l.advance()
+	}
+	text := l.input[begin:l.offset]
+	return token{kind: tokenID, text: text, pos: start, isKey: keywords[strings.ToLower(text)]}
+}
+
+var keywords = map[string]bool{
+	"strict":   true,
+	"graph":    true,
+	"digraph":  true,
+	"subgraph": true,
+	"node":     true,
+	"edge":     true,
+}
@@ -0,0 +1,30 @@
+package dot
+
+import "sort"
+
+type MergedEdge struct {
+	To    string
+	Label string
+}
+
+func GroupByTarget(transitions map[string]string) map[string][]string {
+	symbolsByTarget := make(map[string][]string)
+	for symbol, to := range transitions {
+		symbolsByTarget[to] = append(symbolsByTarget[to], symbol)
+	}
+	return symbolsByTarget
+}
+
+func MergeEdges(symbolsByTarget map[string][]string, compressRanges bool) []MergedEdge {
+	targets := make([]string, 0, len(symbolsByTarget))
+	for to := range symbolsByTarget {
+		targets = append(targets, to)
+	}
+	sort.Strings(targets)
+
+	edges := make([]MergedEdge, 0, len(targets))
+	for _, to := range targets {
+		edges = append(edges, MergedEdge{To: to, Label: FormatLabel(symbolsByTarget[to], compressRanges)})
+	}
+	return edges
+}
@@ -0,0 +1,439 @@
+package dot
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+)
+
+type Node struct {
+	ID    string
+	Attrs map[string]string
+	Pos   Position
+}
+
+type Edge struct {
+	From  string
+	To    string
+	Attrs map[string]string
+	Pos   Position
+}
+
+type Graph struct {
+	Strict    bool
+	Directed  bool
+	ID        string
+	Attrs     map[string]string
+	Nodes     []*Node
+	Edges     []*Edge
+	nodeIndex map[string]*Node
+}
+
+func (g *Graph) Node(id string) (*Node, bool) {
+	node, ok := g.nodeIndex[id]
+	return node, ok
+}
+
+type scope struct {
+	nodeDefaults map[string]string
+	edgeDefaults map[string]string
+	members      []string
+	memberSet    map[string]bool
+}
+
+func (s *scope) child() *scope {
+	return &scope{
+		nodeDefaults: maps.Clone(s.nodeDefaults),
+		edgeDefaults: maps.Clone(s.edgeDefaults),
+		memberSet:    make(map[string]bool),
+	}
+}
+
+func (s *scope) addMember(id string) {
+	if !s.memberSet[id] {
+		s.memberSet[id] = true
+		s.members = append(s.members, id)
+	}
+}
+
+type parser struct {
+	lexer   *lexer
+	current token
+	graph   *Graph
+	scopes  []*scope
+}
+
+func Parse(input string) (*Graph, error) {
+	p := &parser{
+		lexer: newLexer(input),
+		graph: &Graph{
+			Attrs:     make(map[string]string),
+			nodeIndex: make(map[string]*Node),
+		},
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if err := p.parseGraph(); err != nil {
+		return nil, err
+	}
+	return p.graph, nil
+}
+
+func (p *parser) advance() error {
+	t, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+	p.current = t
+	return nil
+}
+
+func (p *parser) errorf(pos Position, format string, args ...any) error {
+	return &Error{Pos: pos, Message: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) unexpected(expected string) error {
+	return p.errorf(p.current.pos, "expected %s, found %s", expected, p.current)
+}
+
+func (p *parser) isKeyword(keyword string) bool {
+	return p.current.kind == tokenID && p.current.isKey && strings.EqualFold(p.current.text, keyword)
+}
+
+func (p *parser) expect(kind tokenKind, expected string) error {
+	if p.current.kind != kind {
+		return p.unexpected(expected)
+	}
+	return p.advance()
+}
+
+func (p *parser) isID() bool {
+	switch p.current.kind {
+	case tokenID:
+		return !p.current.isKey
+	case tokenQuoted, tokenHTML:
+		return true
+	}
+	return false
+}
+
+func (p *parser) parseID() (string, error) {
+	if !p.isID() {
+		return "", p.unexpected("identifier")
+	}
+	if p.current.kind != tokenQuoted {
+		text := p.current.text
+		return text, p.advance()
+	}
+
+	var text strings.Builder
+	text.WriteString(p.current.text)
+	if err := p.advance(); err != nil {
+		return "", err
+	}
+	for p.current.kind == tokenPlus {
+		if err := p.advance(); err != nil {
+			return "", err
+		}
+		if p.current.kind != tokenQuoted {
+			return "", p.unexpected("quoted string after '+'")
+		}
+		text.WriteString(p.current.text)
+		if err := p.advance(); err != nil {
+			return "", err
+		}
+	}
+	return text.String(), nil
+}
+
+func (p *parser) parseGraph() error {
+	if p.isKeyword("strict") {
+		p.graph.Strict = true
+		if err := p.advance(); err != nil {
+			return err
+		}
+	}
+	switch {
+	case p.isKeyword("digraph"):
+		p.graph.Directed = true
+	case p.isKeyword("graph"):
+	default:
+		return p.unexpected("'graph' or 'digraph'")
+	}
+	if err := p.advance(); err != nil {
+		return err
+	}
+
+	if p.isID() {
+		id, err := p.parseID()
+		if err != nil {
+			return err
+		}
+		p.graph.ID = id
+	}
+
+	root := &scope{
+		nodeDefaults: make(map[string]string),
+		edgeDefaults: make(map[string]string),
+		memberSet:    make(map[string]bool),
+	}
+	if _, err := p.parseBlock(root); err != nil {
+		return err
+	}
+	return p.expect(tokenEOF, "end of input")
+}
+
+func (p *parser) parseBlock(s *scope) (*scope, error) {
+	if err := p.expect(tokenLBrace, "'{'"); err != nil {
+		return nil, err
+	}
+	p.scopes = append(p.scopes, s)
+	defer func() { p.scopes = p.scopes[:len(p.scopes)-1] }()
+
+	for p.current.kind != tokenRBrace {
+		if p.current.kind == tokenEOF {
+			return nil, p.unexpected("'}'")
+		}
+		if err := p.parseStatement(s); err != nil {
+			return nil, err
+		}
+		if p.current.kind == tokenSemicolon || p.current.kind == tokenComma {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return s, p.advance()
+}
+
+func (p *parser) parseStatement(s *scope) error {
+	switch {
+	case p.isKeyword("graph"), p.isKeyword("node"), p.isKeyword("edge"):
+		return p.parseAttrStatement(s)
+	case p.isKeyword("subgraph"), p.current.kind == tokenLBrace:
+		members, err := p.parseSubgraph(s)
+		if err != nil {
+			return err
+		}
+		return p.parseEdgeRest(s, members)
+	case p.isID():
+		return p.parseNodeOrEdge(s)
+	}
+	return p.unexpected("statement")
+}
+
+func (p *parser) parseAttrStatement(s *scope) error {
+	kind := strings.ToLower(p.current.text)
+	if err := p.advance(); err != nil {
+		return err
+	}
+	attrs, err := p.parseAttrLists()
+	if err != nil {
+		return err
+	}
+	if attrs == nil {
+		return p.unexpected("'['")
+	}
+
+	switch kind {
+	case "graph":
+		if len(p.scopes) == 1 {
+			maps.Copy(p.graph.Attrs, attrs)
+		}
+	case "node":
+		maps.Copy(s.nodeDefaults, attrs)
+	case "edge":
+		maps.Copy(s.edgeDefaults, attrs)
+	}
+	return nil
+}
+
+func (p *parser) parseAttrLists() (map[string]string, error) {
+	var attrs map[string]string
+	for p.current.kind == tokenLBracket {
+		if attrs == nil {
+			attrs = make(map[string]string)
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		for p.current.kind != tokenRBracket {
+			key, err := p.parseID()
+			if err != nil {
+				return nil, err
+			}
+			if err = p.expect(tokenEqual, "'='"); err != nil {
+				return nil, err
+			}
+			value, err := p.parseID()
+			if err != nil {
+				return nil, err
+			}
+			attrs[key] = value
+
+			if p.current.kind == tokenComma || p.current.kind == tokenSemicolon {
+				if err = p.advance(); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	return attrs, nil
+}
+
+func (p *parser) parseSubgraph(s *scope) ([]string, error) {
+	if p.isKeyword("subgraph") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if p.isID() {
+			if _, err := p.parseID(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	child, err := p.parseBlock(s.child())
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range child.members {
+		s.addMember(id)
+	}
+	return child.members, nil
+}
+
+func (p *parser) parseNodeOrEdge(s *scope) error {
+	pos := p.current.pos
+	id, err := p.parseNodeID()
+	if err != nil {
+		return err
+	}
+	if p.current.kind == tokenEqual {
+		if err = p.advance(); err != nil {
+			return err
+		}
+		value, err := p.parseID()
+		if err != nil {
+			return err
+		}
+		if len(p.scopes) == 1 {
+			p.graph.Attrs[id] = value
+		}
+		return nil
+	}
+
+	if p.current.kind == tokenArrow {
+		p.declareNode(s, id, pos)
+		return p.parseEdgeRest(s, []string{id})
+	}
+
+	attrs, err := p.parseAttrLists()
+	if err != nil {
+		return err
+	}
+	node := p.declareNode(s, id, pos)
+	maps.Copy(node.Attrs, attrs)
+	return nil
+}
+
+func (p *parser) parseNodeID() (string, error) {
+	id, err := p.parseID()
+	if err != nil {
+		return "", err
+	}
+	for i := 0; i < 2 && p.current.kind == tokenColon; i++ {
+		if err = p.advance(); err != nil {
+			return "", err
+		}
+		if _, err = p.parseID(); err != nil {
+			return "", err
+		}
+	}
+	return id, nil
+}
+
+func (p *parser) parseEdgeRest(s *scope, from []string) error {
+	type segment struct {
+		from []string
+		to   []string
+		pos  Position
+	}
+
+	var segments []segment
+	for p.current.kind == tokenArrow {
+		pos := p.current.pos
+		if p.graph.Directed && p.current.text != "->" {
+			return p.errorf(pos, "undirected edge '--' in digraph")
+		}
+		if !p.graph.Directed && p.current.text != "--" {
+			return p.errorf(pos, "directed edge '->' in undirected graph")
+		}
+		if err := p.advance(); err != nil {
+			return err
+		}
+
+		var to []string
+		if p.isKeyword("subgraph") || p.current.kind == tokenLBrace {
+			members, err := p.parseSubgraph(s)
+			if err != nil {
+				return err
+			}
+			to = members
+		} else {
+			nodePos := p.current.pos
+			id, err := p.parseNodeID()
+			if err != nil {
+				return err
+			}
+			p.declareNode(s, id, nodePos)
+			to = []string{id}
+		}
+		segments = append(segments, segment{from: from, to: to, pos: pos})
+		from = to
+	}
+
+	attrs, err := p.parseAttrLists()
+	if err != nil {
+		return err
+	}
+	for _, seg := range segments {
+		for _, f := range seg.from {
+			for _, t := range seg.to {
+				edgeAttrs := maps.Clone(s.edgeDefaults)
+				maps.Copy(edgeAttrs, attrs)
+				p.addEdge(&Edge{From: f, To: t, Attrs: edgeAttrs, Pos: seg.pos})
+			}
+		}
+	}
+	return nil
+}
+
+func (p *parser) declareNode(s *scope, id string, pos Position) *Node {
+	for _, sc := range p.scopes {
+		sc.addMember(id)
+	}
+	if node, ok := p.graph.nodeIndex[id]; ok {
+		return node
+	}
+	node := &Node{ID: id, Attrs: maps.Clone(s.nodeDefaults), Pos: pos}
+	p.graph.nodeIndex[id] = node
+	p.graph.Nodes = append(p.graph.Nodes, node)
+	return node
+}
+
+func (p *parser) addEdge(edge *Edge) {
+	if p.graph.Strict {
+		for _, existing := range p.graph.Edges {
+			if existing.From == edge.From && existing.To == edge.To {
+				maps.Copy(existing.Attrs, edge.Attrs)
+				return
+			}
+		}
+	}
+	p.graph.Edges = append(p.graph.Edges, edge)
+}
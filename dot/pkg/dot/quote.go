@@ -0,0 +1,44 @@
+package dot
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+func QuoteID(id string) string {
+	if isPlainID(id) && !keywords[strings.ToLower(id)] {
+		return id
+	}
+	return `"` + escapeQuoted(id) + `"`
+}
+
+func EscapeLabel(label string) string {
+	return escapeQuoted(label)
+}
+
+func escapeQuoted(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return strings.ReplaceAll(s, `"`, `\"`)
+}
+
+func isPlainID(id string) bool {
+	if id == "" {
+		return false
+	}
+	first, _ := utf8.DecodeRuneInString(id)
+	if unicode.IsDigit(first) {
+		for _, r := range id {
+			if !unicode.IsDigit(r) {
+				return false
+			}
+		}
+		return true
+	}
+	for _, r := range id {
+		if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) && r < utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
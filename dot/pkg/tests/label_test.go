@@ -0,0 +1,109 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"dot/pkg/dot"
+)
+
+func TestSplitLabel(t *testing.T) {
+	testCases := []struct {
+		label    string
+		expected []string
+	}{
+		{"a", []string{"a"}},
+		{"a,b", []string{"a", "b"}},
+		{"a | b", []string{"a", "b"}},
+		{"a-d", []string{"a", "b", "c", "d"}},
+		{"0-2,x|eps", []string{"0", "1", "2", "x", "eps"}},
+		{"a,a", []string{"a"}},
+		{`\,,\|,\-,\\`, []string{",", "|", "-", `\`}},
+		{"q-10", []string{"q-10"}},
+		{`a\-c`, []string{"a-c"}},
+	}
+
+	for _, tc := range testCases {
+		actual, err := dot.SplitLabel(tc.label)
+		if err != nil {
+			t.Errorf("SplitLabel(%q): unexpected error: %v", tc.label, err)
+			continue
+		}
+		if !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("SplitLabel(%q): expected %q, got %q", tc.label, tc.expected, actual)
+		}
+	}
+}
+
+func TestParseLabelMarksEscapedSymbolsAsLiteral(t *testing.T) {
+	expected := []dot.LabelSymbol{
+		{Value: "eps"},
+		{Value: "eps", Literal: true},
+		{Value: "a,b", Literal: true},
+		{Value: "x", Literal: true},
+		{Value: "y", Literal: true},
+		{Value: "ε"},
+	}
+
+	actual, err := dot.ParseLabel(`eps,\eps,a\,b,x-y,ε`)
+	if err != nil {
+		t.Fatalf("ParseLabel: unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("ParseLabel: expected %v, got %v", expected, actual)
+	}
+}
+
+func TestSplitLabelErrors(t *testing.T) {
+	for _, label := range []string{"", "a,,b", "a,", "z-a", `a\`} {
+		if _, err := dot.SplitLabel(label); err == nil {
+			t.Errorf("SplitLabel(%q): expected error", label)
+		}
+	}
+}
+
+func TestFormatLabel(t *testing.T) {
+	testCases := []struct {
+		symbols        []string
+		compressRanges bool
+		expected       string
+	}{
+		{[]string{"b", "a"}, false, "a,b"},
+		{[]string{"c", "a", "b", "d"}, false, "a,b,c,d"},
+		{[]string{"c", "a", "b", "d"}, true, "a-d"},
+		{[]string{"a", "b", "x", "0", "1", "2", "eps"}, true, `0-2,a,b,\eps,x`},
+		{[]string{"ε", "λ"}, false, `\ε,\λ`},
+		{[]string{",", "-", "|"}, true, `\,,\-,\|`},
+	}
+
+	for _, tc := range testCases {
+		actual := dot.FormatLabel(tc.symbols, tc.compressRanges)
+		if actual != tc.expected {
+			t.Errorf("FormatLabel(%q, %v): expected %q, got %q", tc.symbols, tc.compressRanges, tc.expected, actual)
+		}
+
+		roundTrip, err := dot.SplitLabel(actual)
+		if err != nil {
+			t.Errorf("SplitLabel(%q): unexpected error: %v", actual, err)
+		}
+		if len(roundTrip) != len(tc.symbols) {
+			t.Errorf("round trip of %q: expected %d symbols, got %q", tc.symbols, len(tc.symbols), roundTrip)
+		}
+	}
+}
+
+func TestMergeEdges(t *testing.T) {
+	transitions := map[string]string{"a": "q1", "b": "q1", "c": "q1", "x": "q0", `"`: "q0"}
+	expected := []dot.MergedEdge{
+		{To: "q0", Label: `",x`},
+		{To: "q1", Label: "a-c"},
+	}
+
+	actual := dot.MergeEdges(dot.GroupByTarget(transitions), true)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("MergeEdges: expected %v, got %v", expected, actual)
+	}
+	if escaped := dot.EscapeLabel(actual[0].Label); escaped != `\",x` {
+		t.Errorf("EscapeLabel(%q): expected %q, got %q", actual[0].Label, `\",x`, escaped)
+	}
+}
@@ -0,0 +1,150 @@
+package tests
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"dot/pkg/dot"
+)
+
+func TestParseAutomatonFromWriterOutput(t *testing.T) {
+	input := `digraph FiniteStateMachine {
+	rankdir=LR;
+	node [shape = doublecircle]; S1 S2;
+	node [shape = circle];
+	start [shape=point, style=invis];
+	start -> S0;
+	S0 -> S1 [label = "a"];
+	S1 -> S2 [label = "b"];
+}`
+
+	a, err := dot.ParseAutomaton(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(a.StartStates, []string{"S0"}) {
+		t.Errorf("start states: expected [S0], got %v", a.StartStates)
+	}
+	if !reflect.DeepEqual(a.States, []string{"S1", "S2", "S0"}) {
+		t.Errorf("states: expected [S1 S2 S0], got %v", a.States)
+	}
+	if !reflect.DeepEqual(a.Accepting, map[string]bool{"S1": true, "S2": true}) {
+		t.Errorf("accepting: expected S1 and S2, got %v", a.Accepting)
+	}
+	if len(a.Transitions) != 2 || a.Transitions[0].Label != "a" || a.Transitions[1].To != "S2" {
+		t.Errorf("unexpected transitions: %+v", a.Transitions)
+	}
+}
+
+func TestParseSyntaxFeatures(t *testing.T) {
+	input := `/* header
+comment */
+# preprocessor line
+strict digraph "my graph" {
+	graph [rankdir = LR]
+	edge [label = "x"]
+	"quoted \"id\"" [shape = doublecircle
+		color = red];
+	<<b>html</b>>;
+	a -> b -> c [label = "y"]; // trailing comment
+	a:port:n -> "multi" + "part"
+	subgraph cluster { node [shape = box]; d; e }
+	c -> { d e }
+	a -> b [label = "z"]
+}`
+
+	g, err := dot.Parse(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !g.Strict || !g.Directed || g.ID != "my graph" || g.Attrs["rankdir"] != "LR" {
+		t.Errorf("unexpected graph header: %+v", g)
+	}
+
+	quoted, ok := g.Node(`quoted "id"`)
+	if !ok || quoted.Attrs["shape"] != "doublecircle" || quoted.Attrs["color"] != "red" {
+		t.Errorf("quoted node not parsed correctly: %+v", quoted)
+	}
+	if _, ok = g.Node("<b>html</b>"); !ok {
+		t.Errorf("HTML node not found")
+	}
+	if _, ok = g.Node("multipart"); !ok {
+		t.Errorf("concatenated node not found")
+	}
+	if d, _ := g.Node("d"); d == nil || d.Attrs["shape"] != "box" {
+		t.Errorf("subgraph node defaults not applied: %+v", d)
+	}
+	if c, _ := g.Node("c"); c == nil || c.Attrs["shape"] != "" {
+		t.Errorf("subgraph node defaults leaked: %+v", c)
+	}
+
+	var edges []string
+	for _, e := range g.Edges {
+		edges = append(edges, e.From+"-"+e.To+":"+e.Attrs["label"])
+	}
+	expected := []string{"a-b:z", "b-c:y", "a-multipart:x", "c-d:x", "c-e:x"}
+	if !reflect.DeepEqual(edges, expected) {
+		t.Errorf("edges: expected %v, got %v", expected, edges)
+	}
+}
+
+func TestParseErrorsReportPosition(t *testing.T) {
+	testCases := []struct {
+		name   string
+		input  string
+		line   int
+		column int
+	}{
+		{"Missing Bracket", "digraph {\n  a -> b [label = \"a\"\n}", 3, 1},
+		{"Unterminated String", "digraph {\n  a -> b [label = \"a];\n}", 2, 19},
+		{"Undirected Edge", "digraph {\n  a -- b;\n}", 2, 5},
+		{"Unexpected Character", "digraph {\n  a -> b @\n}", 2, 10},
+		{"Missing Label", "digraph {\n  start -> a;\n  a -> b;\n}", 3, 5},
+		{"Unterminated Comment", "digraph {\n  /* a -> b\n}", 2, 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := dot.ParseAutomaton(tc.input)
+			var dotErr *dot.Error
+			if !errors.As(err, &dotErr) {
+				t.Fatalf("expected *dot.Error, got %v", err)
+			}
+			if dotErr.Pos.Line != tc.line || dotErr.Pos.Column != tc.column {
+				t.Errorf("expected error at %d:%d, got %v", tc.line, tc.column, err)
+			}
+		})
+	}
+}
+
+func TestQuoteID(t *testing.T) {
+	testCases := map[string]string{
+		"q0":       "q0",
+		"q0_q1":    "q0_q1",
+		"42":       "42",
+		"{q0,q1}":  `"{q0,q1}"`,
+		"a b":      `"a b"`,
+		`say "hi"`: `"say \"hi\""`,
+		`q\`:       `"q\\"`,
+		`a\"b`:     `"a\\\"b"`,
+		"node":     `"node"`,
+		"":         `""`,
+	}
+
+	for id, expected := range testCases {
+		quoted := dot.QuoteID(id)
+		if quoted != expected {
+			t.Errorf("QuoteID(%q): expected %s, got %s", id, expected, quoted)
+		}
+
+		g, err := dot.Parse("digraph { " + quoted + " }")
+		if err != nil {
+			t.Errorf("failed to parse quoted ID %s: %v", quoted, err)
+			continue
+		}
+		if _, ok := g.Node(id); !ok {
+			t.Errorf("node %q not found after round trip", id)
+		}
+	}
+}
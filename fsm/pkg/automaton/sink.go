@@ -0,0 +1,153 @@
+package automaton
+
+import (
+	"sort"
+	"strconv"
+)
+
+const SinkState = "dead"
+
+func SinkStateName(states []string) string {
+	taken := make(map[string]bool, len(states))
+	for _, state := range states {
+		taken[state] = true
+	}
+	name := SinkState
+	for suffix := 1; taken[name]; suffix++ {
+		name = SinkState + strconv.Itoa(suffix)
+	}
+	return name
+}
+
+func IsComplete(dfa *DFA) bool {
+	for _, state := range dfa.States {
+		for _, symbol := range dfa.Alphabet {
+			if _, ok := dfa.Transitions[state][symbol]; !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func Complete(dfa *DFA) *DFA {
+	result := copyDFA(dfa)
+	if IsComplete(dfa) {
+		return result
+	}
+
+	sink := SinkStateName(dfa.States)
+	result.States = append(result.States, sink)
+	sort.Strings(result.States)
+	for _, state := range result.States {
+		if _, ok := result.Transitions[state]; !ok {
+			result.Transitions[state] = make(map[string]string)
+		}
+		for _, symbol := range result.Alphabet {
+			if _, ok := result.Transitions[state][symbol]; !ok {
+				result.Transitions[state][symbol] = sink
+			}
+		}
+	}
+	return result
+}
+
+func SinkStates(dfa *DFA) []string {
+	reverse := make(map[string][]string)
+	for from, transitions := range dfa.Transitions {
+		for _, to := range transitions {
+			reverse[to] = append(reverse[to], from)
+		}
+	}
+
+	live := make(map[string]bool)
+	var queue []string
+	for state := range dfa.AcceptingStates {
+		if dfa.AcceptingStates[state] {
+			live[state] = true
+			queue = append(queue, state)
+		}
+	}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		for _, from := range reverse[state] {
+			if !live[from] {
+				live[from] = true
+				queue = append(queue, from)
+			}
+		}
+	}
+
+	var sinks []string
+	for _, state := range dfa.States {
+		if !live[state] && state != dfa.StartState {
+			sinks = append(sinks, state)
+		}
+	}
+	sort.Strings(sinks)
+	return sinks
+}
+
+func StripSinks(dfa *DFA) *DFA {
+	sinks := make(map[string]bool)
+	for _, state := range SinkStates(dfa) {
+		sinks[state] = true
+	}
+
+	result := NewDFA()
+	result.Alphabet = append(result.Alphabet, dfa.Alphabet...)
+	result.StartState = dfa.StartState
+	for _, state := range dfa.States {
+		if !sinks[state] {
+			result.States = append(result.States, state)
+		}
+	}
+	for state, accepting := range dfa.AcceptingStates {
+		if accepting {
+			result.AcceptingStates[state] = true
+		}
+	}
+	for from, transitions := range dfa.Transitions {
+		if sinks[from] {
+			continue
+		}
+		for symbol, to := range transitions {
+			if sinks[to] {
+				continue
+			}
+			if _, ok := result.Transitions[from]; !ok {
+				result.Transitions[from] = make(map[string]string)
+			}
+			result.Transitions[from][symbol] = to
+		}
+	}
+	return result
+}
+
+func ApplySinkOptions(dfa *DFA, complete, stripSinks bool) *DFA {
+	if stripSinks {
+		dfa = StripSinks(dfa)
+	}
+	if complete {
+		dfa = Complete(dfa)
+	}
+	return dfa
+}
+
+func copyDFA(dfa *DFA) *DFA {
+	result := NewDFA()
+	result.States = append(result.States, dfa.States...)
+	result.Alphabet = append(result.Alphabet, dfa.Alphabet...)
+	result.StartState = dfa.StartState
+	for state, accepting := range dfa.AcceptingStates {
+		result.AcceptingStates[state] = accepting
+	}
+	for from, transitions := range dfa.Transitions {
+		result.Transitions[from] = make(map[string]string, len(transitions))
+		for symbol, to := range transitions {
+			result.Transitions[from][symbol] = to
+		}
+	}
+	return result
+}
@@ -0,0 +1,68 @@
+package limits
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrStateLimit = errors.New("DFA state limit exceeded")
+
+type Limits struct {
+	MaxStates int
+	MaxTime   time.Duration
+}
+
+type Stats struct {
+	States    int
+	Processed int
+	Elapsed   time.Duration
+}
+
+type LimitError struct {
+	Stats Stats
+	Err   error
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("subset construction stopped: %v (%d states discovered, %d processed, %s elapsed)",
+		e.Err, e.Stats.States, e.Stats.Processed, e.Stats.Elapsed.Round(time.Millisecond))
+}
+
+func (e *LimitError) Unwrap() error {
+	return e.Err
+}
+
+type Limiter struct {
+	ctx    context.Context
+	limits Limits
+	start  time.Time
+}
+
+func NewLimiter(ctx context.Context, limits Limits) (*Limiter, context.CancelFunc) {
+	cancel := context.CancelFunc(func() {})
+	if limits.MaxTime > 0 {
+		ctx, cancel = context.WithTimeout(ctx, limits.MaxTime)
+	}
+	return &Limiter{ctx: ctx, limits: limits, start: time.Now()}, cancel
+}
+
+func (l *Limiter) Context() context.Context {
+	return l.ctx
+}
+
+func (l *Limiter) Check(states, processed int) error {
+	var cause error
+	if err := l.ctx.Err(); err != nil {
+		cause = err
+	} else if l.limits.MaxStates > 0 && states > l.limits.MaxStates {
+		cause = ErrStateLimit
+	} else {
+		return nil
+	}
+	return &LimitError{
+		Stats: Stats{States: states, Processed: processed, Elapsed: time.Since(l.start)},
+		Err:   cause,
+	}
+}
@@ -0,0 +1,51 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"fsm/pkg/limits"
+)
+
+func TestLimiterStateLimit(t *testing.T) {
+	l, cancel := limits.NewLimiter(context.Background(), limits.Limits{MaxStates: 10})
+	defer cancel()
+
+	if err := l.Check(10, 5); err != nil {
+		t.Errorf("Check(10, 5): unexpected error: %v", err)
+	}
+	err := l.Check(11, 6)
+	if !errors.Is(err, limits.ErrStateLimit) {
+		t.Fatalf("Check(11, 6): expected ErrStateLimit, got %v", err)
+	}
+	var limitErr *limits.LimitError
+	if !errors.As(err, &limitErr) || limitErr.Stats.States != 11 || limitErr.Stats.Processed != 6 {
+		t.Errorf("Check(11, 6): unexpected stats in %v", err)
+	}
+}
+
+func TestLimiterTimeLimit(t *testing.T) {
+	l, cancel := limits.NewLimiter(context.Background(), limits.Limits{MaxTime: time.Millisecond})
+	defer cancel()
+
+	<-l.Context().Done()
+	if err := l.Check(1, 0); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Check: expected DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestLimiterCancelled(t *testing.T) {
+	ctx, cancelParent := context.WithCancel(context.Background())
+	l, cancel := limits.NewLimiter(ctx, limits.Limits{})
+	defer cancel()
+
+	if err := l.Check(1000, 1000); err != nil {
+		t.Errorf("Check without limits: unexpected error: %v", err)
+	}
+	cancelParent()
+	if err := l.Check(1, 0); !errors.Is(err, context.Canceled) {
+		t.Errorf("Check: expected Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"fsm/pkg/automaton"
+)
+
+var partialWordDFA = &automaton.DFA{
+	States:   []string{"q0", "q1", "q2"},
+	Alphabet: []string{"a", "b"},
+	Transitions: map[string]map[string]string{
+		"q0": {"a": "q1"}, "q1": {"b": "q2"},
+	},
+	StartState: "q0", AcceptingStates: map[string]bool{"q2": true},
+}
+
+func TestCompleteAddsSinkState(t *testing.T) {
+	complete := automaton.Complete(partialWordDFA)
+	expected := &automaton.DFA{
+		States:   []string{"dead", "q0", "q1", "q2"},
+		Alphabet: []string{"a", "b"},
+		Transitions: map[string]map[string]string{
+			"dead": {"a": "dead", "b": "dead"}, "q0": {"a": "q1", "b": "dead"},
+			"q1": {"a": "dead", "b": "q2"}, "q2": {"a": "dead", "b": "dead"},
+		},
+		StartState: "q0", AcceptingStates: map[string]bool{"q2": true},
+	}
+	if !reflect.DeepEqual(complete, expected) {
+		t.Errorf("Complete: expected %+v, got %+v", expected, complete)
+	}
+
+	if automaton.IsComplete(partialWordDFA) {
+		t.Errorf("Partial DFA reported as complete")
+	}
+	if len(partialWordDFA.States) != 3 {
+		t.Errorf("Complete must not modify its input, got states %v", partialWordDFA.States)
+	}
+	if !reflect.DeepEqual(automaton.StripSinks(complete), partialWordDFA) {
+		t.Errorf("StripSinks must undo Complete, got %+v", automaton.StripSinks(complete))
+	}
+}
+
+func TestSinkStateNameAvoidsCollisions(t *testing.T) {
+	name := automaton.SinkStateName([]string{"q0", automaton.SinkState, automaton.SinkState + "1"})
+	if name != automaton.SinkState+"2" {
+		t.Errorf("SinkStateName: expected %q, got %q", automaton.SinkState+"2", name)
+	}
+}
+
+func TestStripSinksKeepsStartState(t *testing.T) {
+	dfa := &automaton.DFA{
+		States:          []string{"q0", "q1"},
+		Alphabet:        []string{"a"},
+		Transitions:     map[string]map[string]string{"q0": {"a": "q1"}, "q1": {"a": "q1"}},
+		StartState:      "q0",
+		AcceptingStates: map[string]bool{},
+	}
+	stripped := automaton.StripSinks(dfa)
+
+	if len(stripped.States) != 1 || stripped.States[0] != "q0" {
+		t.Errorf("Expected the only state q0, got %v", stripped.States)
+	}
+	if len(stripped.Transitions) != 0 {
+		t.Errorf("Expected no transitions, got %v", stripped.Transitions)
+	}
+}
+
+func TestApplySinkOptions(t *testing.T) {
+	if dfa := automaton.ApplySinkOptions(partialWordDFA, false, false); dfa != partialWordDFA {
+		t.Errorf("ApplySinkOptions without options must return its input")
+	}
+	if dfa := automaton.ApplySinkOptions(partialWordDFA, true, false); !automaton.IsComplete(dfa) {
+		t.Errorf("ApplySinkOptions with complete must return a complete DFA")
+	}
+	if dfa := automaton.ApplySinkOptions(automaton.Complete(partialWordDFA), false, true); len(dfa.States) != 3 {
+		t.Errorf("ApplySinkOptions with stripSinks must remove the sink, got states %v", dfa.States)
+	}
+}
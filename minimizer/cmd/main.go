@@ -5,17 +5,33 @@ import (
 	"fmt"
 	"os"
 
+	"minimisation/pkg/dictionary"
 	"minimisation/pkg/minimizer"
 	"minimisation/pkg/model"
 	"minimisation/pkg/parser"
 	"minimisation/pkg/writer"
 )
 
+type config struct {
+	input      *string
+	output     *string
+	dictionary *string
+	merge      *bool
+	ranges     *bool
+	complete   *bool
+	stripSinks *bool
+}
+
 func main() {
-	inputFile, outputFile := parseInput()
-	assertInput(inputFile, outputFile)
+	c := parseInput()
+	assertInput(c)
+
+	if *c.dictionary != "" {
+		buildDictionary(c)
+		return
+	}
 
-	originalDFA, err := parseDFAFromFile(*inputFile)
+	originalDFA, err := parseDFAFromFile(*c.input)
 	if err != nil {
 		fmt.Printf("Error parsing input file: %v\n", err)
 		os.Exit(1)
@@ -26,13 +42,44 @@ func main() {
 	minimizedDFA := m.Minimize()
 	fmt.Printf("Minimized DFA has %d states.\n", len(minimizedDFA.States))
 
-	w := writer.NewWriter()
-	err = w.WriteToFile(minimizedDFA, *outputFile)
+	w := newWriter(c)
+	err = w.WriteToFile(minimizedDFA, *c.output)
+	if err != nil {
+		fmt.Printf("Error writing output file: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Successfully wrote minimized DFA to %s\n", *c.output)
+}
+
+func buildDictionary(c *config) {
+	words, err := dictionary.ReadWords(*c.dictionary)
+	if err != nil {
+		fmt.Printf("Error reading dictionary file: %v\n", err)
+		os.Exit(1)
+	}
+
+	dfa, err := dictionary.Build(words)
 	if err != nil {
+		fmt.Printf("Error building dictionary DFA: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Built minimal acyclic DFA with %d states for %d words.\n", len(dfa.States), len(words))
+
+	w := newWriter(c)
+	if err = w.WriteToFile(dfa, *c.output); err != nil {
 		fmt.Printf("Error writing output file: %v\n", err)
 		os.Exit(1)
 	}
-	fmt.Printf("Successfully wrote minimized DFA to %s\n", *outputFile)
+	fmt.Printf("Successfully wrote dictionary DFA to %s\n", *c.output)
+}
+
+func newWriter(c *config) *writer.Writer {
+	return writer.NewWriterWithOptions(writer.Options{
+		MergeParallelEdges: *c.merge || *c.ranges,
+		CompressRanges:     *c.ranges,
+		CompleteWithSink:   *c.complete,
+		StripSinkStates:    *c.stripSinks,
+	})
 }
 
 func parseDFAFromFile(filePath string) (*model.DFA, error) {
@@ -45,17 +92,34 @@ func parseDFAFromFile(filePath string) (*model.DFA, error) {
 	return p.Parse()
 }
 
-func parseInput() (*string, *string) {
+func parseInput() *config {
 	inputFile := flag.String("in", "", "Input file in .dot format")
 	outputFile := flag.String("out", "", "Output file for the minimized DFA")
+	dictionaryFile := flag.String("dict", "", "Sorted word list to build a minimal acyclic DFA from")
+	merge := flag.Bool("merge", false, "Merge parallel edges into a single comma-separated label")
+	ranges := flag.Bool("ranges", false, "Merge parallel edges and compress consecutive symbols into ranges like a-z")
+	complete := flag.Bool("complete", false, "Complete the output DFA with an explicit dead state")
+	stripSinks := flag.Bool("strip-sinks", false, "Remove dead states that cannot reach an accepting state")
 	flag.Parse()
 
-	return inputFile, outputFile
+	return &config{
+		input:      inputFile,
+		output:     outputFile,
+		dictionary: dictionaryFile,
+		merge:      merge,
+		ranges:     ranges,
+		complete:   complete,
+		stripSinks: stripSinks,
+	}
 }
 
-func assertInput(inputFile *string, outputFile *string) {
-	if *inputFile == "" || *outputFile == "" {
-		fmt.Println("Usage: go run . -in <input.dot> -out <output.dot>")
+func assertInput(c *config) {
+	if (*c.input == "" && *c.dictionary == "") || *c.output == "" {
+		fmt.Println("Usage: go run . (-in <input.dot> | -dict <words.txt>) -out <output.dot> [-merge] [-ranges] [-complete | -strip-sinks]")
+		os.Exit(1)
+	}
+	if *c.complete && *c.stripSinks {
+		fmt.Println("Flags -complete and -strip-sinks cannot be used together.")
 		os.Exit(1)
 	}
 }
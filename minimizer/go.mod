@@ -1,3 +1,12 @@
 module minimisation
 
 go 1.25
+
+require (
+	dot v0.0.0
+	fsm v0.0.0
+)
+
+replace dot => ../dot
+
+replace fsm => ../fsm
@@ -0,0 +1,168 @@
+package dictionary
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"minimisation/pkg/model"
+)
+
+type edge struct {
+	symbol rune
+	to     int
+}
+
+type state struct {
+	accepting bool
+	edges     []edge
+}
+
+type builder struct {
+	states   []*state
+	register map[string]int
+	previous []rune
+}
+
+func Build(words []string) (*model.DFA, error) {
+	b := &builder{
+		states:   []*state{{}},
+		register: make(map[string]int),
+	}
+
+	for i, word := range words {
+		runes := []rune(word)
+		if i > 0 {
+			if word < words[i-1] {
+				return nil, fmt.Errorf("слова не отсортированы: %q следует после %q", word, words[i-1])
+			}
+			if word == words[i-1] {
+				continue
+			}
+		}
+		b.addWord(runes)
+	}
+	if len(b.states[0].edges) > 0 {
+		b.replaceOrRegister(0)
+	}
+
+	return b.buildDFA(), nil
+}
+
+func ReadWords(filePath string) ([]string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ParseWords(file)
+}
+
+func ParseWords(reader io.Reader) ([]string, error) {
+	var words []string
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		word := strings.TrimSpace(scanner.Text())
+		if word != "" {
+			words = append(words, word)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка чтения строки: %w", err)
+	}
+	return words, nil
+}
+
+func (b *builder) addWord(word []rune) {
+	current := 0
+	prefixLength := 0
+	for prefixLength < len(word) && prefixLength < len(b.previous) && word[prefixLength] == b.previous[prefixLength] {
+		current = b.lastChild(current)
+		prefixLength++
+	}
+
+	if len(b.states[current].edges) > 0 {
+		b.replaceOrRegister(current)
+	}
+
+	for _, r := range word[prefixLength:] {
+		next := len(b.states)
+		b.states = append(b.states, &state{})
+		b.states[current].edges = append(b.states[current].edges, edge{symbol: r, to: next})
+		current = next
+	}
+	b.states[current].accepting = true
+	b.previous = word
+}
+
+func (b *builder) replaceOrRegister(index int) {
+	s := b.states[index]
+	last := len(s.edges) - 1
+	child := s.edges[last].to
+	if len(b.states[child].edges) > 0 {
+		b.replaceOrRegister(child)
+	}
+
+	signature := b.signature(child)
+	if registered, ok := b.register[signature]; ok {
+		s.edges[last].to = registered
+		b.states[child] = nil
+		return
+	}
+	b.register[signature] = child
+}
+
+func (b *builder) lastChild(index int) int {
+	edges := b.states[index].edges
+	return edges[len(edges)-1].to
+}
+
+func (b *builder) signature(index int) string {
+	var signature strings.Builder
+	if b.states[index].accepting {
+		signature.WriteString("+")
+	} else {
+		signature.WriteString("-")
+	}
+	for _, e := range b.states[index].edges {
+		fmt.Fprintf(&signature, "%d:%d,", e.symbol, e.to)
+	}
+	return signature.String()
+}
+
+func (b *builder) buildDFA() *model.DFA {
+	dfa := model.NewDFA()
+	names := map[int]string{0: "S0"}
+	queue := []int{0}
+	alphabetSet := make(map[string]bool)
+
+	for head := 0; head < len(queue); head++ {
+		index := queue[head]
+		name := names[index]
+		dfa.States = append(dfa.States, name)
+		if b.states[index].accepting {
+			dfa.AcceptingStates[name] = true
+		}
+
+		dfa.Transitions[name] = make(map[string]string)
+		for _, e := range b.states[index].edges {
+			if _, ok := names[e.to]; !ok {
+				names[e.to] = fmt.Sprintf("S%d", len(names))
+				queue = append(queue, e.to)
+			}
+			dfa.Transitions[name][string(e.symbol)] = names[e.to]
+			alphabetSet[string(e.symbol)] = true
+		}
+	}
+
+	dfa.StartState = names[0]
+	for symbol := range alphabetSet {
+		dfa.Alphabet = append(dfa.Alphabet, symbol)
+	}
+	sort.Strings(dfa.Alphabet)
+	sort.Strings(dfa.States)
+	return dfa
+}
@@ -1,27 +1,19 @@
 package parser
 
 import (
-	"bufio"
 	"errors"
 	"fmt"
-	"regexp"
 	"sort"
-	"strings"
 
+	"dot/pkg/dot"
 	"minimisation/pkg/model"
 )
 
-var (
-	acceptingStateRegex = regexp.MustCompile(`node\s*\[\s*shape\s*=\s*doublecircle\s*\];\s*([^;]+);`)
-	startStateRegex     = regexp.MustCompile(`start\s*->\s*(\w+);`)
-	transitionRegex     = regexp.MustCompile(`(\w+)\s*->\s*(\w+)\s*\[\s*label\s*=\s*"([^"]+)"\s*\];`)
-)
-
 type Parser struct {
 	dfa         *model.DFA
 	allStates   map[string]bool
 	alphabetSet map[string]bool
-	scanner     *bufio.Scanner
+	dotString   string
 }
 
 func NewParser(dotString string) *Parser {
@@ -29,71 +21,70 @@ func NewParser(dotString string) *Parser {
 		dfa:         model.NewDFA(),
 		allStates:   make(map[string]bool),
 		alphabetSet: make(map[string]bool),
-		scanner:     bufio.NewScanner(strings.NewReader(dotString)),
+		dotString:   dotString,
 	}
 }
 
 func (p *Parser) Parse() (*model.DFA, error) {
-	for p.scanner.Scan() {
-		p.processLine(strings.TrimSpace(p.scanner.Text()))
-	}
-	if err := p.scanner.Err(); err != nil {
-		return nil, fmt.Errorf("ошибка чтения строки: %w", err)
+	automaton, err := dot.ParseAutomaton(p.dotString)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка разбора DOT: %w", err)
 	}
-	return p.finalizeDFA()
-}
-
-func (p *Parser) processLine(line string) {
-	if p.parseAcceptingStates(line) {
-		return
-	}
-	if p.parseStartState(line) {
-		return
-	}
-	p.parseTransition(line)
-}
 
-func (p *Parser) parseAcceptingStates(line string) bool {
-	matches := acceptingStateRegex.FindStringSubmatch(line)
-	if len(matches) < 2 {
-		return false
+	for _, state := range automaton.States {
+		p.allStates[state] = true
 	}
-	for _, state := range strings.Fields(matches[1]) {
+	for state := range automaton.Accepting {
 		p.dfa.AcceptingStates[state] = true
-		p.allStates[state] = true
+	}
+	for _, transition := range automaton.Transitions {
+		if err = p.addTransition(transition); err != nil {
+			return nil, err
+		}
 	}
 
-	return true
+	switch len(automaton.StartStates) {
+	case 0:
+	case 1:
+		p.dfa.StartState = automaton.StartStates[0]
+	default:
+		return nil, fmt.Errorf("ДКА может иметь только одно начальное состояние, найдено: %v", automaton.StartStates)
+	}
+	return p.finalizeDFA()
 }
 
-func (p *Parser) parseStartState(line string) bool {
-	matches := startStateRegex.FindStringSubmatch(line)
-	if len(matches) < 2 {
-		return false
+func (p *Parser) addTransition(transition dot.Transition) error {
+	symbols, err := dot.ParseLabel(transition.Label)
+	if err != nil {
+		return fmt.Errorf("%s: %w", transition.Pos, err)
 	}
-	p.dfa.StartState = matches[1]
-	p.allStates[p.dfa.StartState] = true
-
-	return true
+	for _, symbol := range symbols {
+		if !symbol.Literal && dot.IsEpsilon(symbol.Value, dot.DefaultEpsilonAliases) {
+			return fmt.Errorf("%s: ε-переход из '%s' недопустим в ДКА", transition.Pos, transition.From)
+		}
+		if err = p.addSymbolTransition(transition, symbol.Value); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func (p *Parser) parseTransition(line string) bool {
-	matches := transitionRegex.FindStringSubmatch(line)
-	if len(matches) < 4 {
-		return false
-	}
-	from, to, symbol := matches[1], matches[2], matches[3]
+func (p *Parser) addSymbolTransition(transition dot.Transition, symbol string) error {
+	from, to := transition.From, transition.To
 
 	if _, ok := p.dfa.Transitions[from]; !ok {
 		p.dfa.Transitions[from] = make(map[string]string)
 	}
+	if existing, ok := p.dfa.Transitions[from][symbol]; ok && existing != to {
+		return fmt.Errorf("%s: недетерминированный переход из '%s' по символу '%s'", transition.Pos, from, symbol)
+	}
 	p.dfa.Transitions[from][symbol] = to
 
 	p.allStates[from] = true
 	p.allStates[to] = true
 	p.alphabetSet[symbol] = true
 
-	return true
+	return nil
 }
 
 func (p *Parser) finalizeDFA() (*model.DFA, error) {
@@ -0,0 +1,129 @@
+package tests
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"minimisation/pkg/dictionary"
+	"minimisation/pkg/minimizer"
+	"minimisation/pkg/model"
+)
+
+func buildTrieDFA(words []string) *model.DFA {
+	dfa := model.NewDFA()
+	dfa.StartState = "T0"
+	dfa.States = []string{"T0"}
+	alphabetSet := make(map[string]bool)
+
+	for _, word := range words {
+		current := "T0"
+		for _, r := range word {
+			symbol := string(r)
+			alphabetSet[symbol] = true
+			if _, ok := dfa.Transitions[current]; !ok {
+				dfa.Transitions[current] = make(map[string]string)
+			}
+			next, ok := dfa.Transitions[current][symbol]
+			if !ok {
+				next = fmt.Sprintf("T%d", len(dfa.States))
+				dfa.States = append(dfa.States, next)
+				dfa.Transitions[current][symbol] = next
+			}
+			current = next
+		}
+		dfa.AcceptingStates[current] = true
+	}
+
+	for symbol := range alphabetSet {
+		dfa.Alphabet = append(dfa.Alphabet, symbol)
+	}
+	sort.Strings(dfa.Alphabet)
+	return dfa
+}
+
+func accepts(dfa *model.DFA, word string) bool {
+	current := dfa.StartState
+	for _, r := range word {
+		next, ok := dfa.Transitions[current][string(r)]
+		if !ok {
+			return false
+		}
+		current = next
+	}
+	return dfa.AcceptingStates[current]
+}
+
+func TestDictionaryBuildsMinimalDFA(t *testing.T) {
+	input := "tap\ntaps\ntop\ntops\nstop\nstops\nswap\nswaps\nёж\nёжик\n"
+	words, err := dictionary.ParseWords(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Failed to parse words: %v", err)
+	}
+	sort.Strings(words)
+
+	actualDFA, err := dictionary.Build(words)
+	if err != nil {
+		t.Fatalf("Failed to build dictionary DFA: %v", err)
+	}
+	expectedDFA := minimizer.NewMinimizer(buildTrieDFA(words)).Minimize()
+
+	if len(actualDFA.States) != len(expectedDFA.States) {
+		t.Errorf("States count mismatch:\n got: %d\n want: %d", len(actualDFA.States), len(expectedDFA.States))
+	}
+	if actualDFA.StartState != "S0" {
+		t.Errorf("StartState mismatch:\n got: %s\n want: S0", actualDFA.StartState)
+	}
+
+	probes := append([]string{"", "t", "ta", "tapss", "stopss", "swa", "ёжи", "sto"}, words...)
+	for _, probe := range probes {
+		if accepts(actualDFA, probe) != accepts(expectedDFA, probe) {
+			t.Errorf("Acceptance mismatch for %q: got %v", probe, accepts(actualDFA, probe))
+		}
+	}
+}
+
+func TestDictionaryRejectsUnsortedWords(t *testing.T) {
+	if _, err := dictionary.Build([]string{"b", "a"}); err == nil {
+		t.Error("expected error for unsorted words")
+	}
+}
+
+func TestDictionaryHandlesDuplicatesAndEmptyInput(t *testing.T) {
+	dfa, err := dictionary.Build([]string{"a", "a", "ab"})
+	if err != nil {
+		t.Fatalf("Failed to build dictionary DFA: %v", err)
+	}
+	if len(dfa.States) != 3 || !accepts(dfa, "a") || !accepts(dfa, "ab") || accepts(dfa, "b") {
+		t.Errorf("unexpected DFA for duplicated words: %+v", dfa)
+	}
+
+	empty, err := dictionary.Build(nil)
+	if err != nil {
+		t.Fatalf("Failed to build empty dictionary DFA: %v", err)
+	}
+	if len(empty.States) != 1 || accepts(empty, "") {
+		t.Errorf("unexpected DFA for empty dictionary: %+v", empty)
+	}
+}
+
+func TestDictionaryDistinguishesDigitSymbols(t *testing.T) {
+	words := []string{"+20", "+8", "0+0", "0+1", "0466", "07", "10", "11", "125", "1645", "18++", "3344", "5", "5+6", "500+", "57", "6272", "635", "64", "66", "68", "711", "72", "72+1", "7445", "7532", "788", "799", "8", "9", "91", "937", "97", "9720", "98"}
+
+	actualDFA, err := dictionary.Build(words)
+	if err != nil {
+		t.Fatalf("Failed to build dictionary DFA: %v", err)
+	}
+	expectedDFA := minimizer.NewMinimizer(buildTrieDFA(words)).Minimize()
+
+	if len(actualDFA.States) != len(expectedDFA.States) {
+		t.Errorf("States count mismatch:\n got: %d\n want: %d", len(actualDFA.States), len(expectedDFA.States))
+	}
+	probes := append([]string{"", "+", "9", "97+", "512", "+512", "7"}, words...)
+	for _, probe := range probes {
+		if accepts(actualDFA, probe) != accepts(expectedDFA, probe) {
+			t.Errorf("Acceptance mismatch for %q: got %v", probe, accepts(actualDFA, probe))
+		}
+	}
+}
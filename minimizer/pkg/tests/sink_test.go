@@ -0,0 +1,44 @@
+package tests
+
+import (
+	"testing"
+
+	"fsm/pkg/automaton"
+	"minimisation/pkg/minimizer"
+	"minimisation/pkg/model"
+)
+
+var partialWordDFA = &model.DFA{
+	States:   []string{"q0", "q1", "q2"},
+	Alphabet: []string{"a", "b"},
+	Transitions: map[string]map[string]string{
+		"q0": {"a": "q1"}, "q1": {"b": "q2"},
+	},
+	StartState: "q0", AcceptingStates: map[string]bool{"q2": true},
+}
+
+func TestMinimizeCompleteDFA(t *testing.T) {
+	minimized := minimizer.NewMinimizer(automaton.Complete(partialWordDFA)).Minimize()
+	if !automaton.IsComplete(minimized) {
+		t.Fatalf("Minimized complete DFA is not complete")
+	}
+	if len(minimized.States) != 4 {
+		t.Errorf("Expected 4 states including the sink, got %v", minimized.States)
+	}
+
+	sinks := automaton.SinkStates(minimized)
+	if len(sinks) != 1 {
+		t.Fatalf("Expected exactly one sink state, got %v", sinks)
+	}
+	stripped := automaton.StripSinks(minimized)
+	if len(stripped.States) != 3 {
+		t.Errorf("Expected 3 states after stripping, got %v", stripped.States)
+	}
+	for _, transitions := range stripped.Transitions {
+		for _, to := range transitions {
+			if to == sinks[0] {
+				t.Errorf("Transition into stripped sink state %s remains", to)
+			}
+		}
+	}
+}
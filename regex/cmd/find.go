@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"regex/pkg/search"
+)
+
+const (
+	findCommand = "find"
+	textFormat  = "text"
+	jsonFormat  = "json"
+)
+
+type findConfig struct {
+	input  *string
+	file   *string
+	format *string
+	buffer *int
+}
+
+func runFind(args []string) {
+	c := parseFindFlags(args)
+	assertFindInput(c)
+
+	data, err := os.ReadFile(*c.input)
+	if err != nil {
+		fmt.Printf("Failed to read input file: %v\n", err)
+		os.Exit(1)
+	}
+
+	dfa, err := compileDFA(string(data))
+	if err != nil {
+		fmt.Printf("Failed to compile regular expression: %v\n", err)
+		os.Exit(1)
+	}
+
+	file, err := os.Open(*c.file)
+	if err != nil {
+		fmt.Printf("Failed to open file: %v\n", err)
+		os.Exit(1)
+	}
+	defer file.Close()
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+
+	finder := search.NewFinder(dfa, *c.buffer)
+	err = finder.Find(file, func(m search.Match) error {
+		return writeMatch(out, m, *c.format)
+	})
+	if err != nil {
+		out.Flush()
+		fmt.Printf("Failed to search file: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func writeMatch(out *bufio.Writer, m search.Match, format string) error {
+	if format == jsonFormat {
+		line, err := json.Marshal(m)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(out, "%s\n", line)
+		return err
+	}
+	_, err := fmt.Fprintf(out, "%d:%d:%d: %s\n", m.Line, m.Column, m.Offset, m.Text)
+	return err
+}
+
+func assertFindInput(c *findConfig) {
+	if *c.input == "" || *c.file == "" {
+		fmt.Println("Использование: go run . find -in <input_file> -file <text_file> [-format <text|json>] [-buffer <bytes>]")
+		os.Exit(1)
+	}
+	if *c.format != textFormat && *c.format != jsonFormat {
+		fmt.Printf("Неизвестный формат вывода: %s. Используйте 'text' или 'json'.\n", *c.format)
+		os.Exit(1)
+	}
+}
+
+func parseFindFlags(args []string) *findConfig {
+	flags := flag.NewFlagSet(findCommand, flag.ExitOnError)
+	inputFile := flags.String("in", "", "Входной файл с регулярным выражением")
+	textFile := flags.String("file", "", "Текстовый файл для поиска")
+	format := flags.String("format", textFormat, "Формат вывода: 'text' или 'json' (JSON Lines)")
+	buffer := flags.Int("buffer", search.DefaultBufferSize, "Размер буфера чтения в байтах (ограничивает длину совпадения)")
+	_ = flags.Parse(args)
+
+	return &findConfig{
+		input:  inputFile,
+		file:   textFile,
+		format: format,
+		buffer: buffer,
+	}
+}
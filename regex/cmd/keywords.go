@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+
+	"regex/pkg/ahocorasick"
+	"regex/pkg/writer"
+)
+
+const keywordsCommand = "keywords"
+
+type keywordsConfig struct {
+	dictionary *string
+	output     *string
+	file       *string
+}
+
+func runKeywords(args []string) {
+	c := parseKeywordsFlags(args)
+	assertKeywordsInput(c)
+
+	keywords, err := ahocorasick.ReadDictionary(*c.dictionary)
+	if err != nil {
+		fmt.Printf("Failed to read dictionary: %v\n", err)
+		os.Exit(1)
+	}
+	automaton := ahocorasick.New(keywords)
+
+	if *c.file != "" {
+		data, err := os.ReadFile(*c.file)
+		if err != nil {
+			fmt.Printf("Failed to read file: %v\n", err)
+			os.Exit(1)
+		}
+
+		out := bufio.NewWriter(os.Stdout)
+		for _, m := range automaton.Search(string(data)) {
+			fmt.Fprintf(out, "%d: %s\n", m.Offset, m.Keyword)
+		}
+		out.Flush()
+	}
+
+	if *c.output == "" {
+		return
+	}
+	w := writer.NewWriter()
+	if err = w.WriteToFile(automaton.ToDFA(), *c.output); err != nil {
+		fmt.Printf("Failed to write to output file: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Successfully wrote Aho-Corasick automaton to %s\n", *c.output)
+}
+
+func assertKeywordsInput(c *keywordsConfig) {
+	if *c.dictionary == "" || (*c.output == "" && *c.file == "") {
+		fmt.Println("Использование: go run . keywords -dict <dictionary_file> [-out <output_file>] [-file <text_file>]")
+		os.Exit(1)
+	}
+}
+
+func parseKeywordsFlags(args []string) *keywordsConfig {
+	flags := flag.NewFlagSet(keywordsCommand, flag.ExitOnError)
+	dictionary := flags.String("dict", "", "Файл словаря (по одному ключевому слову на строку)")
+	outputFile := flags.String("out", "", "Выходной файл для автомата Ахо-Корасик")
+	textFile := flags.String("file", "", "Текстовый файл для поиска ключевых слов")
+	_ = flags.Parse(args)
+
+	return &keywordsConfig{
+		dictionary: dictionary,
+		output:     outputFile,
+		file:       textFile,
+	}
+}
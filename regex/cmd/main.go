@@ -1,27 +1,65 @@
 package main
 
 import (
+	"bufio"
+	"context"
 	"flag"
 	"fmt"
 	"log"
 	"os"
+	"regex/pkg/bytelevel"
+	"regex/pkg/compiled"
+	"regex/pkg/levenshtein"
+	"regex/pkg/matcher"
 	"regex/pkg/minimizer"
+	"regex/pkg/model"
 	"regex/pkg/postfix"
 	"regex/pkg/regex"
+	"regex/pkg/simulator"
 	"strings"
+	"time"
 
 	"regex/pkg/determinizer"
 	"regex/pkg/writer"
 )
 
+const (
+	nfaEngine  = "nfa"
+	dfaEngine  = "dfa"
+	lazyEngine = "lazy"
+)
+
 type config struct {
-	input  *string
-	output *string
+	input      *string
+	output     *string
+	strings    *string
+	engine     *string
+	cache      *int
+	bytes      *bool
+	distance   *int
+	alphabet   *string
+	merge      *bool
+	ranges     *bool
+	maxStates  *int
+	maxTime    *time.Duration
+	complete   *bool
+	stripSinks *bool
 }
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case findCommand:
+			runFind(os.Args[2:])
+			return
+		case keywordsCommand:
+			runKeywords(os.Args[2:])
+			return
+		}
+	}
+
 	c := parseCliFlags()
-	assertInput(c.input, c.output)
+	assertInput(c)
 
 	data, err := os.ReadFile(*c.input)
 	if err != nil {
@@ -45,13 +83,54 @@ func main() {
 		os.Exit(1)
 	}
 
-	d := determinizer.NewDeterminizer(nfa)
-	dfa := d.Run()
+	if *c.distance > 0 {
+		nfa = levenshtein.Expand(nfa, *c.distance, strings.Split(*c.alphabet, ""))
+	}
+
+	if *c.bytes {
+		nfa, err = bytelevel.Lower(nfa)
+		if err != nil {
+			fmt.Printf("Failed to lower NFA to UTF-8 bytes: %v\n", err)
+			os.Exit(1)
+		}
+	}
 
-	m := minimizer.NewMinimizer(dfa)
-	minimizedDFA := m.Minimize()
+	var minimizedDFA *model.DFA
+	if *c.output != "" || *c.engine == dfaEngine {
+		d := determinizer.NewDeterminizerWithOptions(nfa, determinizer.Options{Complete: *c.complete})
+		limits := determinizer.Limits{MaxStates: *c.maxStates, MaxTime: *c.maxTime}
+		dfa, err := d.RunContext(context.Background(), limits)
+		if err != nil {
+			fmt.Printf("Failed to determinize NFA: %v\n", err)
+			os.Exit(1)
+		}
 
-	w := writer.NewWriter()
+		m := minimizer.NewMinimizer(dfa)
+		minimizedDFA = m.Minimize()
+	}
+
+	if *c.strings != "" {
+		match, err := buildMatchFunc(c, nfa, minimizedDFA)
+		if err != nil {
+			fmt.Printf("Failed to build matcher: %v\n", err)
+			os.Exit(1)
+		}
+		if err = matchStrings(match, *c.strings); err != nil {
+			fmt.Printf("Failed to match strings: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if *c.output == "" {
+		return
+	}
+
+	w := writer.NewWriterWithOptions(writer.Options{
+		MergeParallelEdges: *c.merge || *c.ranges,
+		CompressRanges:     *c.ranges,
+		CompleteWithSink:   *c.complete,
+		StripSinkStates:    *c.stripSinks,
+	})
 	err = w.WriteToFile(minimizedDFA, *c.output)
 	if err != nil {
 		fmt.Printf("Failed to write to output file: %v\n", err)
@@ -60,9 +139,78 @@ func main() {
 	fmt.Printf("Successfully converted Regular Expression to minimized DFA to %s\n", *c.output)
 }
 
-func assertInput(inputFile *string, outputFile *string) {
-	if *inputFile == "" || *outputFile == "" {
-		fmt.Println("Использование: go run . -in <input_file> -out <output_file>")
+func compileDFA(pattern string) (*model.DFA, error) {
+	inputPostfix, err := postfix.ToPostfix(strings.TrimSpace(pattern))
+	if err != nil {
+		return nil, err
+	}
+
+	nfa, err := regex.NewConverter().ConvertToNFA(inputPostfix)
+	if err != nil {
+		return nil, err
+	}
+
+	dfa := determinizer.NewDeterminizer(nfa).Run()
+	return minimizer.NewMinimizer(dfa).Minimize(), nil
+}
+
+func buildMatchFunc(c *config, nfa *model.NFA, dfa *model.DFA) (func(string) bool, error) {
+	if *c.bytes {
+		byteMatcher, err := bytelevel.NewByteMatcher(dfa)
+		if err != nil {
+			return nil, err
+		}
+		return func(input string) bool {
+			return byteMatcher.Match([]byte(input))
+		}, nil
+	}
+
+	var mt matcher.Matcher
+	switch *c.engine {
+	case nfaEngine:
+		mt = simulator.NewSimulator(nfa)
+	case dfaEngine:
+		mt = compiled.Compile(dfa)
+	case lazyEngine:
+		mt = determinizer.NewLazyDFA(nfa, *c.cache)
+	}
+	return mt.Match, nil
+}
+
+func matchStrings(match func(string) bool, filePath string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if match(line) {
+			fmt.Printf("%q: accepted\n", line)
+		} else {
+			fmt.Printf("%q: rejected\n", line)
+		}
+	}
+	return scanner.Err()
+}
+
+func assertInput(c *config) {
+	if *c.input == "" || (*c.output == "" && *c.strings == "") {
+		fmt.Println("Использование: go run . -in <input_file> [-out <output_file>] [-match <strings_file>] [-engine <nfa|dfa|lazy>] [-cache <bytes>] [-bytes] [-distance <k>] [-alphabet <symbols>] [-merge] [-ranges] [-max-states <n>] [-max-time <duration>] [-complete | -strip-sinks]")
+		os.Exit(1)
+	}
+	if *c.complete && *c.stripSinks {
+		fmt.Println("Флаги -complete и -strip-sinks нельзя использовать одновременно.")
+		os.Exit(1)
+	}
+	if *c.engine != nfaEngine && *c.engine != dfaEngine && *c.engine != lazyEngine {
+		fmt.Printf("Неизвестный движок сопоставления: %s. Используйте 'nfa', 'dfa' или 'lazy'.\n", *c.engine)
+		os.Exit(1)
+	}
+	if *c.bytes && *c.engine != dfaEngine {
+		fmt.Println("Режим -bytes поддерживает только движок 'dfa'.")
 		os.Exit(1)
 	}
 }
@@ -70,10 +218,34 @@ func assertInput(inputFile *string, outputFile *string) {
 func parseCliFlags() *config {
 	inputFile := flag.String("in", "", "Входной файл")
 	outputFile := flag.String("out", "", "Выходной файл")
+	stringsFile := flag.String("match", "", "Файл со строками для проверки (по одной на строку)")
+	engine := flag.String("engine", dfaEngine, "Движок сопоставления: 'nfa' (симуляция НКА), 'dfa' (минимизированный ДКА) или 'lazy' (ленивый ДКА)")
+	cache := flag.Int("cache", determinizer.DefaultLazyCacheSize, "Размер кэша состояний ленивого ДКА в байтах")
+	byteLevel := flag.Bool("bytes", false, "Построить автомат над байтами UTF-8 вместо символов")
+	distance := flag.Int("distance", 0, "Допустимое расстояние Левенштейна до строк языка выражения")
+	alphabet := flag.String("alphabet", "", "Дополнительные символы алфавита для вставок и замен при -distance")
+	merge := flag.Bool("merge", false, "Объединять параллельные рёбра в одно с метками через запятую")
+	ranges := flag.Bool("ranges", false, "Объединять параллельные рёбра и сжимать подряд идущие символы в диапазоны вида a-z")
+	maxStates := flag.Int("max-states", 0, "Максимальное число состояний ДКА при детерминизации (0 — без ограничения)")
+	maxTime := flag.Duration("max-time", 0, "Максимальное время детерминизации, например 30s (0 — без ограничения)")
+	complete := flag.Bool("complete", false, "Достроить ДКА до полного, добавив тупиковое состояние")
+	stripSinks := flag.Bool("strip-sinks", false, "Удалить тупиковые состояния, из которых недостижимы принимающие")
 	flag.Parse()
 
 	return &config{
-		input:  inputFile,
-		output: outputFile,
+		input:      inputFile,
+		output:     outputFile,
+		strings:    stringsFile,
+		engine:     engine,
+		cache:      cache,
+		bytes:      byteLevel,
+		distance:   distance,
+		alphabet:   alphabet,
+		merge:      merge,
+		ranges:     ranges,
+		maxStates:  maxStates,
+		maxTime:    maxTime,
+		complete:   complete,
+		stripSinks: stripSinks,
 	}
 }
@@ -2,10 +2,18 @@ module regex
 
 go 1.25
 
-require github.com/stretchr/testify v1.11.1
+require (
+	dot v0.0.0
+	fsm v0.0.0
+	github.com/stretchr/testify v1.11.1
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+replace dot => ../dot
+
+replace fsm => ../fsm
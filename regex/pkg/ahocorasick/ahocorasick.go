@@ -0,0 +1,179 @@
+package ahocorasick
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"regex/pkg/model"
+)
+
+const (
+	rootNode          = 0
+	dictionaryComment = "#"
+)
+
+type node struct {
+	next    map[rune]int
+	fail    int
+	outputs []int
+}
+
+type Automaton struct {
+	keywords []string
+	nodes    []*node
+	alphabet []rune
+}
+
+type Match struct {
+	Keyword string
+	Offset  int
+}
+
+func New(keywords []string) *Automaton {
+	a := &Automaton{
+		keywords: keywords,
+		nodes:    []*node{newNode()},
+	}
+	alphabetSet := make(map[rune]bool)
+	for i, keyword := range keywords {
+		a.insert(i, keyword, alphabetSet)
+	}
+	for r := range alphabetSet {
+		a.alphabet = append(a.alphabet, r)
+	}
+	sort.Slice(a.alphabet, func(i, j int) bool { return a.alphabet[i] < a.alphabet[j] })
+
+	a.buildFailureLinks()
+	return a
+}
+
+func ReadDictionary(filePath string) ([]string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ParseDictionary(file)
+}
+
+func ParseDictionary(reader io.Reader) ([]string, error) {
+	var keywords []string
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, dictionaryComment) {
+			continue
+		}
+		keywords = append(keywords, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read dictionary: %w", err)
+	}
+	return keywords, nil
+}
+
+func (a *Automaton) Search(text string) []Match {
+	var matches []Match
+	current := rootNode
+	for offset, r := range text {
+		current = a.step(current, r)
+		end := offset + len(string(r))
+		for _, keywordIndex := range a.nodes[current].outputs {
+			keyword := a.keywords[keywordIndex]
+			matches = append(matches, Match{Keyword: keyword, Offset: end - len(keyword)})
+		}
+	}
+	return matches
+}
+
+func (a *Automaton) ToDFA() *model.DFA {
+	dfa := model.NewDFA()
+	dfa.StartState = stateName(rootNode)
+	for _, r := range a.alphabet {
+		dfa.Alphabet = append(dfa.Alphabet, string(r))
+	}
+	sort.Strings(dfa.Alphabet)
+
+	for i, n := range a.nodes {
+		name := stateName(i)
+		dfa.States = append(dfa.States, name)
+		if len(n.outputs) > 0 {
+			dfa.AcceptingStates[name] = true
+		}
+
+		dfa.Transitions[name] = make(map[string]string)
+		for _, r := range a.alphabet {
+			dfa.Transitions[name][string(r)] = stateName(a.step(i, r))
+		}
+	}
+	sort.Strings(dfa.States)
+	return dfa
+}
+
+func (a *Automaton) insert(keywordIndex int, keyword string, alphabetSet map[rune]bool) {
+	current := rootNode
+	for _, r := range keyword {
+		alphabetSet[r] = true
+		next, ok := a.nodes[current].next[r]
+		if !ok {
+			next = len(a.nodes)
+			a.nodes = append(a.nodes, newNode())
+			a.nodes[current].next[r] = next
+		}
+		current = next
+	}
+	a.nodes[current].outputs = append(a.nodes[current].outputs, keywordIndex)
+}
+
+func (a *Automaton) buildFailureLinks() {
+	queue := make([]int, 0, len(a.nodes))
+	for _, r := range a.sortedEdges(rootNode) {
+		child := a.nodes[rootNode].next[r]
+		a.nodes[child].fail = rootNode
+		queue = append(queue, child)
+	}
+
+	for head := 0; head < len(queue); head++ {
+		current := queue[head]
+		for _, r := range a.sortedEdges(current) {
+			child := a.nodes[current].next[r]
+			fail := a.step(a.nodes[current].fail, r)
+			a.nodes[child].fail = fail
+			a.nodes[child].outputs = append(a.nodes[child].outputs, a.nodes[fail].outputs...)
+			queue = append(queue, child)
+		}
+	}
+}
+
+func (a *Automaton) step(current int, r rune) int {
+	for {
+		if next, ok := a.nodes[current].next[r]; ok {
+			return next
+		}
+		if current == rootNode {
+			return rootNode
+		}
+		current = a.nodes[current].fail
+	}
+}
+
+func (a *Automaton) sortedEdges(index int) []rune {
+	edges := make([]rune, 0, len(a.nodes[index].next))
+	for r := range a.nodes[index].next {
+		edges = append(edges, r)
+	}
+	sort.Slice(edges, func(i, j int) bool { return edges[i] < edges[j] })
+	return edges
+}
+
+func newNode() *node {
+	return &node{next: make(map[rune]int)}
+}
+
+func stateName(index int) string {
+	return fmt.Sprintf("S%d", index)
+}
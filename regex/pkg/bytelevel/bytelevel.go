@@ -0,0 +1,174 @@
+package bytelevel
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"regex/pkg/model"
+)
+
+const (
+	hexSymbolPrefix  = "0x"
+	byteStatePrefix  = "B"
+	printableByteMin = 0x21
+	printableByteMax = 0x7E
+)
+
+type edgeKey struct {
+	from string
+	to   string
+}
+
+type lowering struct {
+	nfa          *model.NFA
+	result       *model.NFA
+	alphabetSet  map[string]bool
+	usedStates   map[string]bool
+	stateCounter int
+}
+
+func Lower(nfa *model.NFA) (*model.NFA, error) {
+	l := &lowering{
+		nfa:         nfa,
+		result:      model.NewNFA(),
+		alphabetSet: make(map[string]bool),
+		usedStates:  make(map[string]bool),
+	}
+	for _, state := range nfa.States {
+		l.usedStates[state] = true
+	}
+	l.result.StartState = nfa.StartState
+	l.result.States = append(l.result.States, nfa.States...)
+	for state, accepting := range nfa.AcceptingStates {
+		l.result.AcceptingStates[state] = accepting
+	}
+
+	runesByEdge, err := l.collectRuneEdges()
+	if err != nil {
+		return nil, err
+	}
+
+	edges := make([]edgeKey, 0, len(runesByEdge))
+	for edge := range runesByEdge {
+		edges = append(edges, edge)
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].from != edges[j].from {
+			return edges[i].from < edges[j].from
+		}
+		return edges[i].to < edges[j].to
+	})
+
+	for _, edge := range edges {
+		for _, r := range mergeRanges(runesByEdge[edge]) {
+			for _, sequence := range Sequences(r.lo, r.hi) {
+				l.addSequence(edge.from, edge.to, sequence)
+			}
+		}
+	}
+
+	for symbol := range l.alphabetSet {
+		l.result.Alphabet = append(l.result.Alphabet, symbol)
+	}
+	sort.Strings(l.result.Alphabet)
+	return l.result, nil
+}
+
+func ByteSymbol(b byte) string {
+	if b >= printableByteMin && b <= printableByteMax && b != '"' && b != '\\' {
+		return string(rune(b))
+	}
+	return fmt.Sprintf("%s%02X", hexSymbolPrefix, b)
+}
+
+func ParseByteSymbol(symbol string) (byte, error) {
+	if len(symbol) == 1 {
+		return symbol[0], nil
+	}
+	if !strings.HasPrefix(symbol, hexSymbolPrefix) {
+		return 0, fmt.Errorf("invalid byte symbol: %q", symbol)
+	}
+	value, err := strconv.ParseUint(strings.TrimPrefix(symbol, hexSymbolPrefix), 16, 8)
+	if err != nil {
+		return 0, fmt.Errorf("invalid byte symbol: %q", symbol)
+	}
+	return byte(value), nil
+}
+
+func (l *lowering) collectRuneEdges() (map[edgeKey][]rune, error) {
+	runesByEdge := make(map[edgeKey][]rune)
+	for from, transitions := range l.nfa.Transitions {
+		for symbol, destStates := range transitions {
+			if symbol == model.Epsilon {
+				for _, to := range destStates {
+					l.addTransition(from, symbol, to)
+				}
+				continue
+			}
+
+			r, size := utf8.DecodeRuneInString(symbol)
+			if r == utf8.RuneError || size != len(symbol) {
+				return nil, fmt.Errorf("symbol %q is not a single rune and cannot be lowered to bytes", symbol)
+			}
+			for _, to := range destStates {
+				key := edgeKey{from: from, to: to}
+				runesByEdge[key] = append(runesByEdge[key], r)
+			}
+		}
+	}
+	return runesByEdge, nil
+}
+
+func (l *lowering) addSequence(from, to string, sequence Sequence) {
+	current := from
+	for i, byteRange := range sequence {
+		next := to
+		if i < len(sequence)-1 {
+			next = l.newState()
+		}
+		for b := int(byteRange.Lo); b <= int(byteRange.Hi); b++ {
+			symbol := ByteSymbol(byte(b))
+			l.addTransition(current, symbol, next)
+			l.alphabetSet[symbol] = true
+		}
+		current = next
+	}
+}
+
+func (l *lowering) addTransition(from, symbol, to string) {
+	if _, ok := l.result.Transitions[from]; !ok {
+		l.result.Transitions[from] = make(map[string][]string)
+	}
+	l.result.Transitions[from][symbol] = append(l.result.Transitions[from][symbol], to)
+}
+
+func (l *lowering) newState() string {
+	name := fmt.Sprintf("%s%d", byteStatePrefix, l.stateCounter)
+	for l.usedStates[name] {
+		l.stateCounter++
+		name = fmt.Sprintf("%s%d", byteStatePrefix, l.stateCounter)
+	}
+	l.stateCounter++
+	l.usedStates[name] = true
+	l.result.States = append(l.result.States, name)
+	return name
+}
+
+func mergeRanges(runes []rune) []runeRange {
+	sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+
+	var ranges []runeRange
+	for _, r := range runes {
+		if n := len(ranges); n > 0 && r <= ranges[n-1].hi+1 {
+			if r > ranges[n-1].hi {
+				ranges[n-1].hi = r
+			}
+			continue
+		}
+		ranges = append(ranges, runeRange{lo: r, hi: r})
+	}
+	return ranges
+}
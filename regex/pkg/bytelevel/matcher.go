@@ -0,0 +1,61 @@
+package bytelevel
+
+import (
+	"fmt"
+
+	"regex/pkg/model"
+)
+
+const deadState = -1
+
+type ByteMatcher struct {
+	table     [][256]int32
+	accepting []bool
+	start     int32
+}
+
+func NewByteMatcher(dfa *model.DFA) (*ByteMatcher, error) {
+	indices := make(map[string]int32, len(dfa.States))
+	for i, state := range dfa.States {
+		indices[state] = int32(i)
+	}
+	if _, ok := indices[dfa.StartState]; !ok {
+		return nil, fmt.Errorf("start state %q is not among DFA states", dfa.StartState)
+	}
+
+	m := &ByteMatcher{
+		table:     make([][256]int32, len(dfa.States)),
+		accepting: make([]bool, len(dfa.States)),
+		start:     indices[dfa.StartState],
+	}
+	for i, state := range dfa.States {
+		for b := range m.table[i] {
+			m.table[i][b] = deadState
+		}
+		m.accepting[i] = dfa.AcceptingStates[state]
+
+		for symbol, to := range dfa.Transitions[state] {
+			b, err := ParseByteSymbol(symbol)
+			if err != nil {
+				return nil, err
+			}
+			toIndex, ok := indices[to]
+			if !ok {
+				return nil, fmt.Errorf("transition from %q leads to unknown state %q", state, to)
+			}
+			m.table[i][b] = toIndex
+		}
+	}
+	return m, nil
+}
+
+func (m *ByteMatcher) Match(input []byte) bool {
+	current := m.start
+	for _, b := range input {
+		current = m.table[current][b]
+		if current == deadState {
+			return false
+		}
+	}
+	return m.accepting[current]
+}
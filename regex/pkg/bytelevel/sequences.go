@@ -0,0 +1,104 @@
+package bytelevel
+
+import "unicode/utf8"
+
+const (
+	surrogateMin = 0xD800
+	surrogateMax = 0xDFFF
+)
+
+var encodedLengthBounds = []rune{0x7F, 0x7FF, 0xFFFF}
+
+type ByteRange struct {
+	Lo byte
+	Hi byte
+}
+
+type Sequence []ByteRange
+
+type runeRange struct {
+	lo rune
+	hi rune
+}
+
+func Sequences(lo, hi rune) []Sequence {
+	if lo > hi {
+		return nil
+	}
+	if hi > utf8.MaxRune {
+		hi = utf8.MaxRune
+	}
+
+	var sequences []Sequence
+	stack := []runeRange{{lo: lo, hi: hi}}
+	for len(stack) > 0 {
+		r := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if parts, ok := splitSurrogates(r); ok {
+			stack = append(stack, parts...)
+			continue
+		}
+		if parts, ok := splitByLength(r); ok {
+			stack = append(stack, parts...)
+			continue
+		}
+		if parts, ok := splitByContinuation(r); ok {
+			stack = append(stack, parts...)
+			continue
+		}
+		sequences = append(sequences, encodeRange(r))
+	}
+	return sequences
+}
+
+func splitSurrogates(r runeRange) ([]runeRange, bool) {
+	if r.hi < surrogateMin || r.lo > surrogateMax {
+		return nil, false
+	}
+
+	var parts []runeRange
+	if r.hi > surrogateMax {
+		parts = append(parts, runeRange{lo: surrogateMax + 1, hi: r.hi})
+	}
+	if r.lo < surrogateMin {
+		parts = append(parts, runeRange{lo: r.lo, hi: surrogateMin - 1})
+	}
+	return parts, true
+}
+
+func splitByLength(r runeRange) ([]runeRange, bool) {
+	for _, bound := range encodedLengthBounds {
+		if r.lo <= bound && r.hi > bound {
+			return []runeRange{{lo: bound + 1, hi: r.hi}, {lo: r.lo, hi: bound}}, true
+		}
+	}
+	return nil, false
+}
+
+func splitByContinuation(r runeRange) ([]runeRange, bool) {
+	for i := 1; i < utf8.UTFMax; i++ {
+		mask := rune(1)<<(6*i) - 1
+		if r.lo&^mask == r.hi&^mask {
+			continue
+		}
+		if r.lo&mask != 0 {
+			return []runeRange{{lo: (r.lo | mask) + 1, hi: r.hi}, {lo: r.lo, hi: r.lo | mask}}, true
+		}
+		if r.hi&mask != mask {
+			return []runeRange{{lo: r.hi &^ mask, hi: r.hi}, {lo: r.lo, hi: (r.hi &^ mask) - 1}}, true
+		}
+	}
+	return nil, false
+}
+
+func encodeRange(r runeRange) Sequence {
+	loBytes := utf8.AppendRune(nil, r.lo)
+	hiBytes := utf8.AppendRune(nil, r.hi)
+
+	sequence := make(Sequence, len(loBytes))
+	for i := range loBytes {
+		sequence[i] = ByteRange{Lo: loBytes[i], Hi: hiBytes[i]}
+	}
+	return sequence
+}
@@ -0,0 +1,177 @@
+package compiled
+
+import (
+	"sort"
+	"unicode/utf8"
+
+	"regex/pkg/bitset"
+	"regex/pkg/model"
+)
+
+const Dead int32 = -1
+
+const Threshold = 256
+
+type DFA struct {
+	Names     []string
+	Symbols   []string
+	Table     []int32
+	Accepting bitset.Set
+	Start     int32
+
+	asciiColumns  [utf8.RuneSelf]int32
+	runeColumns   map[rune]int32
+	symbolColumns map[string]int32
+}
+
+func NewDFA(symbols []string) *DFA {
+	d := &DFA{
+		Symbols:       symbols,
+		runeColumns:   make(map[rune]int32),
+		symbolColumns: make(map[string]int32, len(symbols)),
+	}
+	for i := range d.asciiColumns {
+		d.asciiColumns[i] = Dead
+	}
+	for column, symbol := range symbols {
+		d.symbolColumns[symbol] = int32(column)
+		r, size := utf8.DecodeRuneInString(symbol)
+		if r == utf8.RuneError || size != len(symbol) {
+			continue
+		}
+		if r < utf8.RuneSelf {
+			d.asciiColumns[r] = int32(column)
+		} else {
+			d.runeColumns[r] = int32(column)
+		}
+	}
+	return d
+}
+
+func Compile(dfa *model.DFA) *DFA {
+	d := NewDFA(dfa.Alphabet)
+
+	indices := make(map[string]int32, len(dfa.States))
+	for _, state := range dfa.States {
+		indices[state] = d.AddState(state)
+	}
+	if _, ok := indices[dfa.StartState]; !ok {
+		indices[dfa.StartState] = d.AddState(dfa.StartState)
+	}
+	d.Start = indices[dfa.StartState]
+
+	for from, transitions := range dfa.Transitions {
+		fromIndex, ok := indices[from]
+		if !ok {
+			continue
+		}
+		for symbol, to := range transitions {
+			column, ok := d.symbolColumns[symbol]
+			if !ok {
+				continue
+			}
+			toIndex, ok := indices[to]
+			if !ok {
+				toIndex = d.AddState(to)
+				indices[to] = toIndex
+			}
+			d.SetTransition(fromIndex, column, toIndex)
+		}
+	}
+
+	for state, accepting := range dfa.AcceptingStates {
+		if index, ok := indices[state]; ok && accepting {
+			d.Accepting.Add(int(index))
+		}
+	}
+	return d
+}
+
+func (d *DFA) AddState(name string) int32 {
+	index := int32(len(d.Names))
+	d.Names = append(d.Names, name)
+	for range d.Symbols {
+		d.Table = append(d.Table, Dead)
+	}
+	if len(d.Accepting)*64 < len(d.Names) {
+		d.Accepting = append(d.Accepting, 0)
+	}
+	return index
+}
+
+func (d *DFA) NumStates() int {
+	return len(d.Names)
+}
+
+func (d *DFA) NumSymbols() int {
+	return len(d.Symbols)
+}
+
+func (d *DFA) SetTransition(from, column, to int32) {
+	d.Table[int(from)*len(d.Symbols)+int(column)] = to
+}
+
+func (d *DFA) Next(from, column int32) int32 {
+	return d.Table[int(from)*len(d.Symbols)+int(column)]
+}
+
+func (d *DFA) IsAccepting(state int32) bool {
+	return d.Accepting.Has(int(state))
+}
+
+func (d *DFA) Column(r rune) int32 {
+	if r >= 0 && r < utf8.RuneSelf {
+		return d.asciiColumns[r]
+	}
+	if column, ok := d.runeColumns[r]; ok {
+		return column
+	}
+	return Dead
+}
+
+func (d *DFA) SymbolColumn(symbol string) int32 {
+	if column, ok := d.symbolColumns[symbol]; ok {
+		return column
+	}
+	return Dead
+}
+
+func (d *DFA) Match(input string) bool {
+	current := d.Start
+	for _, r := range input {
+		column := d.Column(r)
+		if column == Dead {
+			return false
+		}
+		current = d.Next(current, column)
+		if current == Dead {
+			return false
+		}
+	}
+	return d.IsAccepting(current)
+}
+
+func (d *DFA) ToModel() *model.DFA {
+	dfa := model.NewDFA()
+	dfa.Alphabet = append(dfa.Alphabet, d.Symbols...)
+	dfa.States = append(dfa.States, d.Names...)
+	sort.Strings(dfa.States)
+	dfa.StartState = d.Names[d.Start]
+
+	for from, name := range d.Names {
+		if d.IsAccepting(int32(from)) {
+			dfa.AcceptingStates[name] = true
+		}
+		for column, symbol := range d.Symbols {
+			to := d.Next(int32(from), int32(column))
+			if to == Dead {
+				continue
+			}
+			if _, ok := dfa.Transitions[name]; !ok {
+				dfa.Transitions[name] = make(map[string]string)
+			}
+			dfa.Transitions[name][symbol] = d.Names[to]
+		}
+	}
+	return dfa
+}
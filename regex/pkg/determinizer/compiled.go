@@ -0,0 +1,154 @@
+package determinizer
+
+import (
+	"context"
+	"sort"
+
+	"fsm/pkg/automaton"
+	"regex/pkg/bitset"
+	"regex/pkg/compiled"
+	"regex/pkg/model"
+)
+
+type indexedNFA struct {
+	names       []string
+	accepting   bitset.Set
+	closures    []bitset.Set
+	transitions [][][]int
+}
+
+func (d *Determinizer) RunCompiled() *model.DFA {
+	dfa, _ := d.RunCompiledContext(context.Background(), Limits{})
+	return dfa
+}
+
+func (d *Determinizer) RunCompiledContext(ctx context.Context, limits Limits) (*model.DFA, error) {
+	l, cancel := newLimiter(ctx, limits)
+	defer cancel()
+
+	nfa := indexNFA(d.nfa, d.dfa.Alphabet)
+	result := compiled.NewDFA(d.dfa.Alphabet)
+
+	subsets := []bitset.Set{nfa.closures[nfa.index(d.nfa.StartState)]}
+	subsetIndices := map[string]int32{subsets[0].Key(): result.AddState("")}
+	result.Start = 0
+
+	moveResult := bitset.New(len(nfa.names))
+	for current := 0; current < len(subsets); current++ {
+		if err := l.Check(len(subsets), current); err != nil {
+			return nil, err
+		}
+		for column := range d.dfa.Alphabet {
+			moveResult.Clear()
+			subsets[current].ForEach(func(state int) {
+				for _, dest := range nfa.transitions[state][column] {
+					moveResult.Union(nfa.closures[dest])
+				}
+			})
+			if moveResult.IsEmpty() && !d.options.Complete {
+				continue
+			}
+
+			key := moveResult.Key()
+			to, exists := subsetIndices[key]
+			if !exists {
+				to = result.AddState("")
+				subsetIndices[key] = to
+				subsets = append(subsets, moveResult.Clone())
+			}
+			result.SetTransition(int32(current), int32(column), to)
+		}
+	}
+
+	for index, subset := range subsets {
+		result.Names[index] = nfa.subsetName(subset)
+		if subset.Intersects(nfa.accepting) {
+			result.Accepting.Add(index)
+		}
+	}
+	return result.ToModel(), nil
+}
+
+func indexNFA(nfa *model.NFA, alphabet []string) *indexedNFA {
+	stateSet := map[string]bool{nfa.StartState: true}
+	for _, state := range nfa.States {
+		stateSet[state] = true
+	}
+	for from, transitions := range nfa.Transitions {
+		stateSet[from] = true
+		for _, destStates := range transitions {
+			for _, dest := range destStates {
+				stateSet[dest] = true
+			}
+		}
+	}
+
+	n := &indexedNFA{}
+	for state := range stateSet {
+		n.names = append(n.names, state)
+	}
+	sort.Strings(n.names)
+
+	columns := make(map[string]int, len(alphabet))
+	for column, symbol := range alphabet {
+		columns[symbol] = column
+	}
+
+	epsilon := make([][]int, len(n.names))
+	n.accepting = bitset.New(len(n.names))
+	n.transitions = make([][][]int, len(n.names))
+	for index, state := range n.names {
+		if nfa.AcceptingStates[state] {
+			n.accepting.Add(index)
+		}
+		n.transitions[index] = make([][]int, len(alphabet))
+		for symbol, destStates := range nfa.Transitions[state] {
+			for _, dest := range destStates {
+				destIndex := n.index(dest)
+				if symbol == model.Epsilon {
+					epsilon[index] = append(epsilon[index], destIndex)
+				} else if column, ok := columns[symbol]; ok {
+					n.transitions[index][column] = append(n.transitions[index][column], destIndex)
+				}
+			}
+		}
+	}
+
+	n.closures = make([]bitset.Set, len(n.names))
+	for index := range n.names {
+		n.closures[index] = closureOf(index, epsilon, len(n.names))
+	}
+	return n
+}
+
+func closureOf(state int, epsilon [][]int, size int) bitset.Set {
+	closureSet := bitset.New(size)
+	closureSet.Add(state)
+	stack := []int{state}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, dest := range epsilon[current] {
+			if !closureSet.Has(dest) {
+				closureSet.Add(dest)
+				stack = append(stack, dest)
+			}
+		}
+	}
+	return closureSet
+}
+
+func (n *indexedNFA) index(state string) int {
+	return sort.SearchStrings(n.names, state)
+}
+
+func (n *indexedNFA) subsetName(subset bitset.Set) string {
+	if subset.IsEmpty() {
+		return automaton.SinkStateName(n.names)
+	}
+	states := make([]string, 0, subset.Count())
+	subset.ForEach(func(state int) {
+		states = append(states, n.names[state])
+	})
+	return makeStateName(states)
+}
@@ -1,14 +1,23 @@
 package determinizer
 
 import (
+	"context"
 	"sort"
 	"strings"
 
+	"fsm/pkg/automaton"
+	"regex/pkg/compiled"
 	"regex/pkg/model"
 )
 
+type Options struct {
+	Complete          bool
+	CompiledThreshold int
+}
+
 type Determinizer struct {
 	nfa        *model.NFA
+	options    Options
 	dfa        *model.DFA
 	dStates    [][]string
 	dStatesMap map[string]int
@@ -16,8 +25,13 @@ type Determinizer struct {
 }
 
 func NewDeterminizer(nfa *model.NFA) *Determinizer {
+	return NewDeterminizerWithOptions(nfa, Options{})
+}
+
+func NewDeterminizerWithOptions(nfa *model.NFA, options Options) *Determinizer {
 	d := &Determinizer{
 		nfa:        nfa,
+		options:    options,
 		dfa:        model.NewDFA(),
 		dStatesMap: make(map[string]int),
 	}
@@ -34,14 +48,36 @@ func NewDeterminizer(nfa *model.NFA) *Determinizer {
 }
 
 func (d *Determinizer) Run() *model.DFA {
-	for len(d.queue) > 0 {
+	dfa, _ := d.RunContext(context.Background(), Limits{})
+	return dfa
+}
+
+func (d *Determinizer) RunContext(ctx context.Context, limits Limits) (*model.DFA, error) {
+	if len(d.nfa.States) >= d.compiledThreshold() {
+		return d.RunCompiledContext(ctx, limits)
+	}
+
+	l, cancel := newLimiter(ctx, limits)
+	defer cancel()
+
+	for processed := 0; len(d.queue) > 0; processed++ {
+		if err := l.Check(len(d.dStates), processed); err != nil {
+			return nil, err
+		}
 		currentIndex := d.queue[0]
 		d.queue = d.queue[1:]
 		d.processState(currentIndex)
 	}
 	d.finalizeDFA()
 
-	return d.dfa
+	return d.dfa, nil
+}
+
+func (d *Determinizer) compiledThreshold() int {
+	if d.options.CompiledThreshold > 0 {
+		return d.options.CompiledThreshold
+	}
+	return compiled.Threshold
 }
 
 func (d *Determinizer) initializeStartState() {
@@ -56,11 +92,11 @@ func (d *Determinizer) initializeStartState() {
 
 func (d *Determinizer) processState(stateIndex int) {
 	T := d.dStates[stateIndex]
-	fromStateName := makeStateName(T)
+	fromStateName := d.stateName(T)
 
 	for _, symbol := range d.dfa.Alphabet {
 		moveResult := move(T, symbol, d.nfa)
-		if len(moveResult) == 0 {
+		if len(moveResult) == 0 && !d.options.Complete {
 			continue
 		}
 
@@ -75,7 +111,7 @@ func (d *Determinizer) processState(stateIndex int) {
 }
 
 func (d *Determinizer) registerDFAState(nfaStates []string) (string, bool) {
-	name := makeStateName(nfaStates)
+	name := d.stateName(nfaStates)
 	if _, exists := d.dStatesMap[name]; exists {
 		return name, false
 	}
@@ -163,6 +199,13 @@ func move(states []string, symbol string, nfa *model.NFA) []string {
 	return result
 }
 
+func (d *Determinizer) stateName(states []string) string {
+	if len(states) == 0 {
+		return automaton.SinkStateName(d.nfa.States)
+	}
+	return makeStateName(states)
+}
+
 func makeStateName(states []string) string {
 	return strings.Join(states, "_")
 }
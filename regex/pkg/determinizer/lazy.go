@@ -0,0 +1,134 @@
+package determinizer
+
+import "regex/pkg/model"
+
+const (
+	DefaultLazyCacheSize = 1 << 20
+
+	lazyStateCost      = 64
+	lazyNFAStateCost   = 16
+	lazyTransitionCost = 16
+)
+
+type lazyState struct {
+	nfaStates []string
+	accepting bool
+	next      map[rune]*lazyState
+}
+
+type LazyDFA struct {
+	nfa       *model.NFA
+	cacheSize int
+	cacheUsed int
+	flushes   int
+	states    map[string]*lazyState
+	start     *lazyState
+	dead      *lazyState
+}
+
+func NewLazyDFA(nfa *model.NFA, cacheSize int) *LazyDFA {
+	if cacheSize <= 0 {
+		cacheSize = DefaultLazyCacheSize
+	}
+	l := &LazyDFA{
+		nfa:       nfa,
+		cacheSize: cacheSize,
+		dead:      &lazyState{},
+	}
+	l.resetCache()
+	return l
+}
+
+func (l *LazyDFA) Match(input string) bool {
+	current := l.start
+	for _, r := range input {
+		next, ok := current.next[r]
+		if !ok {
+			next = l.computeNext(current, r)
+		}
+		if next == l.dead {
+			return false
+		}
+		current = next
+	}
+	return current.accepting
+}
+
+func (l *LazyDFA) CachedStates() int {
+	return len(l.states)
+}
+
+func (l *LazyDFA) Flushes() int {
+	return l.flushes
+}
+
+func (l *LazyDFA) computeNext(from *lazyState, r rune) *lazyState {
+	moveResult := move(from.nfaStates, string(r), l.nfa)
+	if len(moveResult) == 0 {
+		l.addTransition(from, r, l.dead)
+		return l.dead
+	}
+
+	closure := epsilonClosure(moveResult, l.nfa)
+	name := makeStateName(closure)
+	to, exists := l.states[name]
+	if !exists {
+		if l.cacheUsed+stateCost(name, closure) > l.cacheSize {
+			from = l.flush(from)
+			to, exists = l.states[name]
+		}
+		if !exists {
+			to = l.registerState(name, closure)
+		}
+	}
+	l.addTransition(from, r, to)
+	return to
+}
+
+func (l *LazyDFA) registerState(name string, nfaStates []string) *lazyState {
+	state := &lazyState{
+		nfaStates: nfaStates,
+		next:      make(map[rune]*lazyState),
+	}
+	for _, nfaState := range nfaStates {
+		if l.nfa.AcceptingStates[nfaState] {
+			state.accepting = true
+			break
+		}
+	}
+
+	l.states[name] = state
+	l.cacheUsed += stateCost(name, nfaStates)
+	return state
+}
+
+func (l *LazyDFA) addTransition(from *lazyState, r rune, to *lazyState) {
+	if l.cacheUsed+lazyTransitionCost > l.cacheSize {
+		return
+	}
+	from.next[r] = to
+	l.cacheUsed += lazyTransitionCost
+}
+
+func (l *LazyDFA) flush(current *lazyState) *lazyState {
+	l.flushes++
+	l.resetCache()
+
+	name := makeStateName(current.nfaStates)
+	if retained, ok := l.states[name]; ok {
+		return retained
+	}
+	return l.registerState(name, current.nfaStates)
+}
+
+func (l *LazyDFA) resetCache() {
+	l.states = make(map[string]*lazyState)
+	l.cacheUsed = 0
+
+	startSet := epsilonClosure([]string{l.nfa.StartState}, l.nfa)
+	l.start = l.registerState(makeStateName(startSet), startSet)
+}
+
+func stateCost(name string, nfaStates []string) int {
+	return lazyStateCost + len(name) + lazyNFAStateCost*len(nfaStates)
+}
@@ -0,0 +1,150 @@
+package levenshtein
+
+import (
+	"fmt"
+	"sort"
+
+	"regex/pkg/determinizer"
+	"regex/pkg/minimizer"
+	"regex/pkg/model"
+)
+
+const wordStatePrefix = "W"
+
+type expander struct {
+	nfa      *model.NFA
+	result   *model.NFA
+	maxEdits int
+	alphabet []string
+}
+
+func NewNFA(word string, maxEdits int, alphabet []string) *model.NFA {
+	return Expand(wordNFA(word), maxEdits, alphabet)
+}
+
+func NewDFA(word string, maxEdits int, alphabet []string) *model.DFA {
+	dfa := determinizer.NewDeterminizer(NewNFA(word, maxEdits, alphabet)).Run()
+	return minimizer.NewMinimizer(dfa).Minimize()
+}
+
+func Expand(nfa *model.NFA, maxEdits int, alphabet []string) *model.NFA {
+	e := &expander{
+		nfa:      nfa,
+		result:   model.NewNFA(),
+		maxEdits: maxEdits,
+		alphabet: mergeAlphabets(nfa.Alphabet, alphabet),
+	}
+	e.result.Alphabet = e.alphabet
+	e.result.StartState = stateName(nfa.StartState, 0)
+
+	for _, state := range collectStates(nfa) {
+		for edits := 0; edits <= maxEdits; edits++ {
+			e.expandState(state, edits)
+		}
+	}
+	return e.result
+}
+
+func (e *expander) expandState(state string, edits int) {
+	from := stateName(state, edits)
+	e.result.States = append(e.result.States, from)
+	if e.nfa.AcceptingStates[state] {
+		e.result.AcceptingStates[from] = true
+	}
+
+	canEdit := edits < e.maxEdits
+	if canEdit {
+		for _, symbol := range e.alphabet {
+			e.addTransition(from, symbol, stateName(state, edits+1))
+		}
+	}
+
+	for symbol, destStates := range e.nfa.Transitions[state] {
+		for _, dest := range destStates {
+			e.addTransition(from, symbol, stateName(dest, edits))
+			if symbol == model.Epsilon || !canEdit {
+				continue
+			}
+			e.addTransition(from, model.Epsilon, stateName(dest, edits+1))
+			for _, substitute := range e.alphabet {
+				if substitute != symbol {
+					e.addTransition(from, substitute, stateName(dest, edits+1))
+				}
+			}
+		}
+	}
+}
+
+func (e *expander) addTransition(from, symbol, to string) {
+	if _, ok := e.result.Transitions[from]; !ok {
+		e.result.Transitions[from] = make(map[string][]string)
+	}
+	e.result.Transitions[from][symbol] = append(e.result.Transitions[from][symbol], to)
+}
+
+func wordNFA(word string) *model.NFA {
+	nfa := model.NewNFA()
+	alphabetSet := make(map[string]bool)
+
+	i := 0
+	nfa.StartState = fmt.Sprintf("%s%d", wordStatePrefix, i)
+	nfa.States = append(nfa.States, nfa.StartState)
+	for _, r := range word {
+		from := fmt.Sprintf("%s%d", wordStatePrefix, i)
+		to := fmt.Sprintf("%s%d", wordStatePrefix, i+1)
+		nfa.Transitions[from] = map[string][]string{string(r): {to}}
+		nfa.States = append(nfa.States, to)
+		alphabetSet[string(r)] = true
+		i++
+	}
+	nfa.AcceptingStates[fmt.Sprintf("%s%d", wordStatePrefix, i)] = true
+
+	for symbol := range alphabetSet {
+		nfa.Alphabet = append(nfa.Alphabet, symbol)
+	}
+	return nfa
+}
+
+func collectStates(nfa *model.NFA) []string {
+	stateSet := map[string]bool{nfa.StartState: true}
+	for _, state := range nfa.States {
+		stateSet[state] = true
+	}
+	for from, transitions := range nfa.Transitions {
+		stateSet[from] = true
+		for _, destStates := range transitions {
+			for _, dest := range destStates {
+				stateSet[dest] = true
+			}
+		}
+	}
+
+	states := make([]string, 0, len(stateSet))
+	for state := range stateSet {
+		states = append(states, state)
+	}
+	sort.Strings(states)
+	return states
+}
+
+func mergeAlphabets(alphabets ...[]string) []string {
+	symbolSet := make(map[string]bool)
+	for _, alphabet := range alphabets {
+		for _, symbol := range alphabet {
+			if symbol != model.Epsilon {
+				symbolSet[symbol] = true
+			}
+		}
+	}
+
+	symbols := make([]string, 0, len(symbolSet))
+	for symbol := range symbolSet {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+func stateName(state string, edits int) string {
+	return fmt.Sprintf("%s/%d", state, edits)
+}
@@ -0,0 +1,27 @@
+package matcher
+
+import "regex/pkg/model"
+
+type Matcher interface {
+	Match(input string) bool
+}
+
+type DFAMatcher struct {
+	dfa *model.DFA
+}
+
+func NewDFAMatcher(dfa *model.DFA) *DFAMatcher {
+	return &DFAMatcher{dfa: dfa}
+}
+
+func (m *DFAMatcher) Match(input string) bool {
+	current := m.dfa.StartState
+	for _, r := range input {
+		next, ok := m.dfa.Transitions[current][string(r)]
+		if !ok {
+			return false
+		}
+		current = next
+	}
+	return m.dfa.AcceptingStates[current]
+}
@@ -0,0 +1,123 @@
+package minimizer
+
+import (
+	"fmt"
+	"sort"
+
+	"regex/pkg/compiled"
+	"regex/pkg/model"
+)
+
+type partitionKey struct {
+	partition int32
+	dest      int32
+}
+
+func (m *Minimizer) MinimizeCompiled() *model.DFA {
+	if len(m.dfa.States) == 0 {
+		return m.dfa
+	}
+	m.removeUnreachableStates()
+	if len(m.dfa.States) <= 1 {
+		return m.dfa
+	}
+	return m.minimizeCompiled()
+}
+
+func (m *Minimizer) minimizeCompiled() *model.DFA {
+	c := compiled.Compile(m.dfa)
+	partitions := refineCompiledPartitions(c)
+
+	groups := make(map[int32][]int32)
+	for state, partition := range partitions {
+		groups[partition] = append(groups[partition], int32(state))
+	}
+
+	representatives := make([]int32, 0, len(groups))
+	for _, states := range groups {
+		representative := states[0]
+		for _, state := range states[1:] {
+			if c.Names[state] < c.Names[representative] {
+				representative = state
+			}
+		}
+		representatives = append(representatives, representative)
+	}
+	startPartition := partitions[c.Start]
+	sort.Slice(representatives, func(i, j int) bool {
+		isStartI := partitions[representatives[i]] == startPartition
+		isStartJ := partitions[representatives[j]] == startPartition
+		if isStartI != isStartJ {
+			return isStartI
+		}
+		return c.Names[representatives[i]] < c.Names[representatives[j]]
+	})
+
+	newNames := make(map[int32]string, len(representatives))
+	for i, representative := range representatives {
+		newNames[partitions[representative]] = fmt.Sprintf("S%d", i)
+	}
+
+	minDFA := model.NewDFA()
+	minDFA.Alphabet = m.dfa.Alphabet
+	minDFA.StartState = newNames[startPartition]
+	for _, representative := range representatives {
+		newState := newNames[partitions[representative]]
+		minDFA.States = append(minDFA.States, newState)
+		if c.IsAccepting(representative) {
+			minDFA.AcceptingStates[newState] = true
+		}
+
+		minDFA.Transitions[newState] = make(map[string]string)
+		for column, symbol := range c.Symbols {
+			if dest := c.Next(representative, int32(column)); dest != compiled.Dead {
+				minDFA.Transitions[newState][symbol] = newNames[partitions[dest]]
+			}
+		}
+	}
+	sort.Strings(minDFA.States)
+	return minDFA
+}
+
+func refineCompiledPartitions(c *compiled.DFA) []int32 {
+	partitions := make([]int32, c.NumStates())
+	hasAccepting, hasNonAccepting := false, false
+	for state := range partitions {
+		if c.IsAccepting(int32(state)) {
+			partitions[state] = 1
+			hasAccepting = true
+		} else {
+			hasNonAccepting = true
+		}
+	}
+	count := 1
+	if hasAccepting && hasNonAccepting {
+		count = 2
+	}
+
+	next := make([]int32, len(partitions))
+	for count < len(partitions) {
+		previousCount := count
+		for column := 0; column < c.NumSymbols(); column++ {
+			ids := make(map[partitionKey]int32)
+			for state := range partitions {
+				key := partitionKey{partition: partitions[state], dest: -1}
+				if dest := c.Next(int32(state), int32(column)); dest != compiled.Dead {
+					key.dest = partitions[dest]
+				}
+				id, ok := ids[key]
+				if !ok {
+					id = int32(len(ids))
+					ids[key] = id
+				}
+				next[state] = id
+			}
+			partitions, next = next, partitions
+			count = len(ids)
+		}
+		if count == previousCount {
+			break
+		}
+	}
+	return partitions
+}
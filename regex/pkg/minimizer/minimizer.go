@@ -5,17 +5,27 @@ import (
 	"sort"
 	"strings"
 
+	"regex/pkg/compiled"
 	"regex/pkg/model"
 )
 
+type Options struct {
+	CompiledThreshold int
+}
+
 type Minimizer struct {
 	dfa           *model.DFA
+	options       Options
 	partitions    map[string]int
 	numPartitions int
 }
 
 func NewMinimizer(dfa *model.DFA) *Minimizer {
-	return &Minimizer{dfa: dfa}
+	return NewMinimizerWithOptions(dfa, Options{})
+}
+
+func NewMinimizerWithOptions(dfa *model.DFA, options Options) *Minimizer {
+	return &Minimizer{dfa: dfa, options: options}
 }
 
 func (m *Minimizer) Minimize() *model.DFA {
@@ -26,11 +36,21 @@ func (m *Minimizer) Minimize() *model.DFA {
 	if len(m.dfa.States) <= 1 {
 		return m.dfa
 	}
+	if len(m.dfa.States) >= m.compiledThreshold() {
+		return m.minimizeCompiled()
+	}
 	m.initializePartitions()
 	m.refinePartitions()
 	return m.buildMinimizedDFA()
 }
 
+func (m *Minimizer) compiledThreshold() int {
+	if m.options.CompiledThreshold > 0 {
+		return m.options.CompiledThreshold
+	}
+	return compiled.Threshold
+}
+
 func (m *Minimizer) initializePartitions() {
 	m.partitions = make(map[string]int)
 	hasAccepting := false
@@ -1,16 +1,9 @@
 package model
 
-type DFA struct {
-	States          []string
-	Alphabet        []string
-	Transitions     map[string]map[string]string
-	StartState      string
-	AcceptingStates map[string]bool
-}
+import "fsm/pkg/automaton"
+
+type DFA = automaton.DFA
 
 func NewDFA() *DFA {
-	return &DFA{
-		Transitions:     make(map[string]map[string]string),
-		AcceptingStates: make(map[string]bool),
-	}
+	return automaton.NewDFA()
 }
@@ -3,6 +3,7 @@ package postfix
 import (
 	"fmt"
 	"strings"
+	"unicode"
 )
 
 var opPrecedence = map[rune]int{
@@ -63,16 +64,17 @@ func ToPostfix(regex string) (string, error) {
 }
 
 func isOperand(r rune) bool {
-	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == 'ε'
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == 'ε'
 }
 
 func insertConcat(regex string) string {
 	var result strings.Builder
-	for i := 0; i < len(regex); i++ {
-		result.WriteByte(regex[i])
-		if i+1 < len(regex) {
-			curr := rune(regex[i])
-			next := rune(regex[i+1])
+	runes := []rune(regex)
+	for i := 0; i < len(runes); i++ {
+		result.WriteRune(runes[i])
+		if i+1 < len(runes) {
+			curr := runes[i]
+			next := runes[i+1]
 
 			if (isOperand(curr) || curr == ')' || curr == '*' || curr == '+') && (isOperand(next) || next == '(') {
 				result.WriteRune('.')
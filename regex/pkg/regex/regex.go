@@ -51,7 +51,7 @@ func (c *Converter) ConvertToNFA(postfix string) (*model.NFA, error) {
 	}
 
 	if len(c.stack) != 1 {
-		return nil, fmt.Errorf("error: stack must contain one NFA fragment, but contains %d: %v")
+		return nil, fmt.Errorf("error: stack must contain one NFA fragment, but contains %d", len(c.stack))
 	}
 
 	finalFragment := c.stack[0]
@@ -69,7 +69,7 @@ func (c *Converter) handleOperand(r rune) error {
 
 func (c *Converter) handleConcatenation() error {
 	if len(c.stack) < 2 {
-		return fmt.Errorf("concat error: not enough operands (at least 2 required): %v")
+		return fmt.Errorf("concat error: not enough operands (at least 2 required)")
 	}
 	frag2 := c.stack[len(c.stack)-1]
 	frag1 := c.stack[len(c.stack)-2]
@@ -83,7 +83,7 @@ func (c *Converter) handleConcatenation() error {
 
 func (c *Converter) handleAlternation() error {
 	if len(c.stack) < 2 {
-		return fmt.Errorf("model.Epsilon error: not enough operands (at least 2 required): %v")
+		return fmt.Errorf("alternation error: not enough operands (at least 2 required)")
 	}
 	frag2 := c.stack[len(c.stack)-1]
 	frag1 := c.stack[len(c.stack)-2]
@@ -104,7 +104,7 @@ func (c *Converter) handleAlternation() error {
 
 func (c *Converter) handleKleenStar() error {
 	if len(c.stack) < 1 {
-		return fmt.Errorf("kleen star error: not enough operands (at least 1 required): %v")
+		return fmt.Errorf("kleen star error: not enough operands (at least 1 required)")
 	}
 	frag := c.stack[len(c.stack)-1]
 	c.stack = c.stack[:len(c.stack)-1]
@@ -123,7 +123,7 @@ func (c *Converter) handleKleenStar() error {
 
 func (c *Converter) handleKleenPlus() error {
 	if len(c.stack) < 1 {
-		return fmt.Errorf("kleen plus error: not enough operands (at least 1 required): %v")
+		return fmt.Errorf("kleen plus error: not enough operands (at least 1 required)")
 	}
 	frag := c.stack[len(c.stack)-1]
 	c.stack = c.stack[:len(c.stack)-1]
@@ -0,0 +1,186 @@
+package search
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf8"
+
+	"regex/pkg/model"
+)
+
+const DefaultBufferSize = 64 * 1024
+
+var errBufferFull = errors.New("buffer is full")
+
+type Match struct {
+	Offset int64  `json:"offset"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+	Text   string `json:"match"`
+}
+
+type Finder struct {
+	dfa        *model.DFA
+	live       map[string]bool
+	bufferSize int
+}
+
+type scanner struct {
+	reader io.Reader
+	buf    []byte
+	base   int64
+	pos    int
+	line   int
+	column int
+	eof    bool
+}
+
+func NewFinder(dfa *model.DFA, bufferSize int) *Finder {
+	if bufferSize < utf8.UTFMax {
+		bufferSize = DefaultBufferSize
+	}
+	return &Finder{dfa: dfa, live: liveStates(dfa), bufferSize: bufferSize}
+}
+
+func (f *Finder) Find(reader io.Reader, emit func(Match) error) error {
+	s := &scanner{
+		reader: reader,
+		buf:    make([]byte, 0, f.bufferSize),
+		line:   1,
+		column: 1,
+	}
+
+	for {
+		if err := s.ensure(0); err != nil {
+			return err
+		}
+		if s.pos >= len(s.buf) {
+			return nil
+		}
+
+		length, err := f.longestMatch(s)
+		if err != nil {
+			return err
+		}
+		if length == 0 {
+			_, size := utf8.DecodeRune(s.buf[s.pos:])
+			s.advance(size)
+			continue
+		}
+
+		match := Match{
+			Offset: s.base + int64(s.pos),
+			Line:   s.line,
+			Column: s.column,
+			Text:   string(s.buf[s.pos : s.pos+length]),
+		}
+		if err = emit(match); err != nil {
+			return err
+		}
+		s.advance(length)
+	}
+}
+
+func (f *Finder) longestMatch(s *scanner) (int, error) {
+	start := s.base + int64(s.pos)
+	state := f.dfa.StartState
+	lastAccept := 0
+	for length := 0; ; {
+		err := s.ensure(length)
+		if errors.Is(err, errBufferFull) {
+			if f.live[state] {
+				return 0, fmt.Errorf("match attempt starting at offset %d exceeds buffer size %d", start, cap(s.buf))
+			}
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+		if s.pos+length >= len(s.buf) {
+			break
+		}
+
+		r, size := utf8.DecodeRune(s.buf[s.pos+length:])
+		next, ok := f.dfa.Transitions[state][string(r)]
+		if !ok {
+			break
+		}
+		state = next
+		length += size
+		if f.dfa.AcceptingStates[state] {
+			lastAccept = length
+		}
+	}
+	return lastAccept, nil
+}
+
+func liveStates(dfa *model.DFA) map[string]bool {
+	reverse := make(map[string][]string)
+	for from, transitions := range dfa.Transitions {
+		for _, to := range transitions {
+			reverse[to] = append(reverse[to], from)
+		}
+	}
+
+	live := make(map[string]bool)
+	var queue []string
+	for state, accepting := range dfa.AcceptingStates {
+		if accepting {
+			live[state] = true
+			queue = append(queue, state)
+		}
+	}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		for _, from := range reverse[state] {
+			if !live[from] {
+				live[from] = true
+				queue = append(queue, from)
+			}
+		}
+	}
+	return live
+}
+
+func (s *scanner) ensure(length int) error {
+	for !s.eof && (s.pos+length >= len(s.buf) || !utf8.FullRune(s.buf[s.pos+length:])) {
+		if err := s.fill(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *scanner) fill() error {
+	if len(s.buf) == cap(s.buf) {
+		if s.pos == 0 {
+			return errBufferFull
+		}
+		n := copy(s.buf, s.buf[s.pos:])
+		s.buf = s.buf[:n]
+		s.base += int64(s.pos)
+		s.pos = 0
+	}
+
+	n, err := s.reader.Read(s.buf[len(s.buf):cap(s.buf)])
+	s.buf = s.buf[:len(s.buf)+n]
+	if errors.Is(err, io.EOF) {
+		s.eof = true
+		return nil
+	}
+	return err
+}
+
+func (s *scanner) advance(length int) {
+	for _, r := range string(s.buf[s.pos : s.pos+length]) {
+		if r == '\n' {
+			s.line++
+			s.column = 1
+		} else {
+			s.column++
+		}
+	}
+	s.pos += length
+}
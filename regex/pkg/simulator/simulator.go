@@ -0,0 +1,124 @@
+package simulator
+
+import (
+	"unicode/utf8"
+
+	"regex/pkg/bitset"
+	"regex/pkg/model"
+)
+
+type Simulator struct {
+	numStates   int
+	startState  int
+	accepting   bitset.Set
+	epsilon     [][]int
+	transitions []map[rune][]int
+	closures    []bitset.Set
+	current     bitset.Set
+	next        bitset.Set
+}
+
+func NewSimulator(nfa *model.NFA) *Simulator {
+	indices := indexStates(nfa)
+	s := &Simulator{
+		numStates:   len(indices),
+		startState:  indices[nfa.StartState],
+		accepting:   bitset.New(len(indices)),
+		epsilon:     make([][]int, len(indices)),
+		transitions: make([]map[rune][]int, len(indices)),
+		closures:    make([]bitset.Set, len(indices)),
+		current:     bitset.New(len(indices)),
+		next:        bitset.New(len(indices)),
+	}
+
+	for state, index := range indices {
+		if nfa.AcceptingStates[state] {
+			s.accepting.Add(index)
+		}
+		s.transitions[index] = make(map[rune][]int)
+		for symbol, destStates := range nfa.Transitions[state] {
+			for _, dest := range destStates {
+				s.addTransition(index, symbol, indices[dest])
+			}
+		}
+	}
+	return s
+}
+
+func (s *Simulator) Match(input string) bool {
+	s.current.Clear()
+	s.current.Union(s.closure(s.startState))
+
+	for _, r := range input {
+		s.next.Clear()
+		s.current.ForEach(func(state int) {
+			for _, dest := range s.transitions[state][r] {
+				s.next.Union(s.closure(dest))
+			}
+		})
+		if s.next.IsEmpty() {
+			return false
+		}
+		s.current, s.next = s.next, s.current
+	}
+
+	return s.current.Intersects(s.accepting)
+}
+
+func (s *Simulator) addTransition(from int, symbol string, to int) {
+	if symbol == model.Epsilon {
+		s.epsilon[from] = append(s.epsilon[from], to)
+		return
+	}
+	r, size := utf8.DecodeRuneInString(symbol)
+	if size != len(symbol) {
+		return
+	}
+	s.transitions[from][r] = append(s.transitions[from][r], to)
+}
+
+func (s *Simulator) closure(state int) bitset.Set {
+	if cached := s.closures[state]; cached != nil {
+		return cached
+	}
+
+	closureSet := bitset.New(s.numStates)
+	closureSet.Add(state)
+	stack := []int{state}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, dest := range s.epsilon[current] {
+			if !closureSet.Has(dest) {
+				closureSet.Add(dest)
+				stack = append(stack, dest)
+			}
+		}
+	}
+
+	s.closures[state] = closureSet
+	return closureSet
+}
+
+func indexStates(nfa *model.NFA) map[string]int {
+	indices := make(map[string]int)
+	register := func(state string) {
+		if _, exists := indices[state]; !exists {
+			indices[state] = len(indices)
+		}
+	}
+
+	register(nfa.StartState)
+	for _, state := range nfa.States {
+		register(state)
+	}
+	for from, transitions := range nfa.Transitions {
+		register(from)
+		for _, destStates := range transitions {
+			for _, dest := range destStates {
+				register(dest)
+			}
+		}
+	}
+	return indices
+}
@@ -0,0 +1,75 @@
+package symbolic
+
+import "sort"
+
+type Edge struct {
+	Predicate Predicate
+	To        string
+}
+
+type NFA struct {
+	States          []string
+	Transitions     map[string][]Edge
+	Epsilon         map[string][]string
+	StartState      string
+	AcceptingStates map[string]bool
+}
+
+func NewNFA() *NFA {
+	return &NFA{
+		Transitions:     make(map[string][]Edge),
+		Epsilon:         make(map[string][]string),
+		AcceptingStates: make(map[string]bool),
+	}
+}
+
+type DFA struct {
+	States          []string
+	Transitions     map[string][]Edge
+	StartState      string
+	AcceptingStates map[string]bool
+}
+
+func NewDFA() *DFA {
+	return &DFA{
+		Transitions:     make(map[string][]Edge),
+		AcceptingStates: make(map[string]bool),
+	}
+}
+
+func (d *DFA) Next(state string, r rune) (string, bool) {
+	for _, edge := range d.Transitions[state] {
+		if edge.Predicate.Contains(r) {
+			return edge.To, true
+		}
+	}
+	return "", false
+}
+
+func (d *DFA) Match(input string) bool {
+	current := d.StartState
+	for _, r := range input {
+		next, ok := d.Next(current, r)
+		if !ok {
+			return false
+		}
+		current = next
+	}
+	return d.AcceptingStates[current]
+}
+
+func mergeEdges(edges []Edge) []Edge {
+	byTarget := make(map[string]Predicate)
+	for _, edge := range edges {
+		byTarget[edge.To] = byTarget[edge.To].Union(edge.Predicate)
+	}
+
+	merged := make([]Edge, 0, len(byTarget))
+	for to, predicate := range byTarget {
+		merged = append(merged, Edge{Predicate: predicate, To: to})
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].Predicate[0].Lo < merged[j].Predicate[0].Lo
+	})
+	return merged
+}
@@ -0,0 +1,151 @@
+package symbolic
+
+import (
+	"fmt"
+	"sort"
+	"unicode/utf8"
+
+	"regex/pkg/model"
+)
+
+func FromNFA(nfa *model.NFA) (*NFA, error) {
+	result := NewNFA()
+	result.States = append(result.States, nfa.States...)
+	result.StartState = nfa.StartState
+	for state, accepting := range nfa.AcceptingStates {
+		result.AcceptingStates[state] = accepting
+	}
+
+	for from, transitions := range nfa.Transitions {
+		var edges []Edge
+		for symbol, destStates := range transitions {
+			if symbol == model.Epsilon {
+				result.Epsilon[from] = append(result.Epsilon[from], destStates...)
+				continue
+			}
+			r, err := symbolRune(symbol)
+			if err != nil {
+				return nil, err
+			}
+			for _, to := range destStates {
+				edges = append(edges, Edge{Predicate: Rune(r), To: to})
+			}
+		}
+		if len(edges) > 0 {
+			result.Transitions[from] = mergeEdges(edges)
+		}
+	}
+	return result, nil
+}
+
+func FromDFA(dfa *model.DFA) (*DFA, error) {
+	result := NewDFA()
+	result.States = append(result.States, dfa.States...)
+	result.StartState = dfa.StartState
+	for state, accepting := range dfa.AcceptingStates {
+		result.AcceptingStates[state] = accepting
+	}
+
+	for from, transitions := range dfa.Transitions {
+		var edges []Edge
+		for symbol, to := range transitions {
+			r, err := symbolRune(symbol)
+			if err != nil {
+				return nil, err
+			}
+			edges = append(edges, Edge{Predicate: Rune(r), To: to})
+		}
+		if len(edges) > 0 {
+			result.Transitions[from] = mergeEdges(edges)
+		}
+	}
+	return result, nil
+}
+
+func ToNFA(nfa *NFA, maxAlphabetSize int) (*model.NFA, error) {
+	result := model.NewNFA()
+	result.States = append(result.States, nfa.States...)
+	result.StartState = nfa.StartState
+	for state, accepting := range nfa.AcceptingStates {
+		result.AcceptingStates[state] = accepting
+	}
+
+	var alphabet Predicate
+	for from, edges := range nfa.Transitions {
+		for _, edge := range edges {
+			alphabet = alphabet.Union(edge.Predicate)
+			if alphabet.Size() > maxAlphabetSize {
+				return nil, fmt.Errorf("alphabet exceeds %d symbols and cannot be made explicit", maxAlphabetSize)
+			}
+			forEachRune(edge.Predicate, func(r rune) {
+				addNFATransition(result, from, string(r), edge.To)
+			})
+		}
+	}
+	for from, destStates := range nfa.Epsilon {
+		for _, to := range destStates {
+			addNFATransition(result, from, model.Epsilon, to)
+		}
+	}
+
+	result.Alphabet = alphabetSymbols(alphabet)
+	return result, nil
+}
+
+func ToDFA(dfa *DFA, maxAlphabetSize int) (*model.DFA, error) {
+	result := model.NewDFA()
+	result.States = append(result.States, dfa.States...)
+	result.StartState = dfa.StartState
+	for state, accepting := range dfa.AcceptingStates {
+		result.AcceptingStates[state] = accepting
+	}
+
+	var alphabet Predicate
+	for from, edges := range dfa.Transitions {
+		result.Transitions[from] = make(map[string]string)
+		for _, edge := range edges {
+			alphabet = alphabet.Union(edge.Predicate)
+			if alphabet.Size() > maxAlphabetSize {
+				return nil, fmt.Errorf("alphabet exceeds %d symbols and cannot be made explicit", maxAlphabetSize)
+			}
+			forEachRune(edge.Predicate, func(r rune) {
+				result.Transitions[from][string(r)] = edge.To
+			})
+		}
+	}
+
+	result.Alphabet = alphabetSymbols(alphabet)
+	return result, nil
+}
+
+func addNFATransition(nfa *model.NFA, from, symbol, to string) {
+	if _, ok := nfa.Transitions[from]; !ok {
+		nfa.Transitions[from] = make(map[string][]string)
+	}
+	nfa.Transitions[from][symbol] = append(nfa.Transitions[from][symbol], to)
+}
+
+func forEachRune(p Predicate, fn func(r rune)) {
+	for _, r := range p {
+		for c := r.Lo; c <= r.Hi; c++ {
+			fn(c)
+		}
+	}
+}
+
+func alphabetSymbols(alphabet Predicate) []string {
+	symbols := make([]string, 0, alphabet.Size())
+	forEachRune(alphabet, func(r rune) {
+		symbols = append(symbols, string(r))
+	})
+	sort.Strings(symbols)
+	return symbols
+}
+
+func symbolRune(symbol string) (rune, error) {
+	r, size := utf8.DecodeRuneInString(symbol)
+	if r == utf8.RuneError || size != len(symbol) {
+		return 0, fmt.Errorf("symbol %q is not a single rune", symbol)
+	}
+	return r, nil
+}
@@ -0,0 +1,128 @@
+package symbolic
+
+import (
+	"sort"
+	"strings"
+)
+
+type determinizer struct {
+	nfa        *NFA
+	dfa        *DFA
+	dStates    [][]string
+	dStatesMap map[string]int
+	queue      []int
+}
+
+func Determinize(nfa *NFA) *DFA {
+	d := &determinizer{
+		nfa:        nfa,
+		dfa:        NewDFA(),
+		dStatesMap: make(map[string]int),
+	}
+
+	startName, _ := d.registerState(d.epsilonClosure([]string{nfa.StartState}))
+	d.dfa.StartState = startName
+
+	for len(d.queue) > 0 {
+		current := d.queue[0]
+		d.queue = d.queue[1:]
+		d.processState(current)
+	}
+	d.finalize()
+	return d.dfa
+}
+
+func (d *determinizer) processState(index int) {
+	T := d.dStates[index]
+	fromName := makeStateName(T)
+
+	var edges []Edge
+	for _, state := range T {
+		edges = append(edges, d.nfa.Transitions[state]...)
+	}
+	predicates := make([]Predicate, len(edges))
+	for i, edge := range edges {
+		predicates[i] = edge.Predicate
+	}
+
+	var dfaEdges []Edge
+	for _, minterm := range Minterms(predicates) {
+		representative := minterm[0].Lo
+		destSet := make(map[string]bool)
+		for _, edge := range edges {
+			if edge.Predicate.Contains(representative) {
+				destSet[edge.To] = true
+			}
+		}
+
+		moveResult := make([]string, 0, len(destSet))
+		for state := range destSet {
+			moveResult = append(moveResult, state)
+		}
+		toName, _ := d.registerState(d.epsilonClosure(moveResult))
+		dfaEdges = append(dfaEdges, Edge{Predicate: minterm, To: toName})
+	}
+
+	if len(dfaEdges) > 0 {
+		d.dfa.Transitions[fromName] = mergeEdges(dfaEdges)
+	}
+}
+
+func (d *determinizer) registerState(nfaStates []string) (string, bool) {
+	name := makeStateName(nfaStates)
+	if _, exists := d.dStatesMap[name]; exists {
+		return name, false
+	}
+
+	index := len(d.dStates)
+	d.dStatesMap[name] = index
+	d.dStates = append(d.dStates, nfaStates)
+	d.queue = append(d.queue, index)
+	return name, true
+}
+
+func (d *determinizer) finalize() {
+	for name, index := range d.dStatesMap {
+		d.dfa.States = append(d.dfa.States, name)
+		for _, nfaState := range d.dStates[index] {
+			if d.nfa.AcceptingStates[nfaState] {
+				d.dfa.AcceptingStates[name] = true
+				break
+			}
+		}
+	}
+	sort.Strings(d.dfa.States)
+}
+
+func (d *determinizer) epsilonClosure(states []string) []string {
+	closureSet := make(map[string]bool)
+	stack := make([]string, 0, len(states))
+	for _, state := range states {
+		if !closureSet[state] {
+			closureSet[state] = true
+			stack = append(stack, state)
+		}
+	}
+
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, dest := range d.nfa.Epsilon[current] {
+			if !closureSet[dest] {
+				closureSet[dest] = true
+				stack = append(stack, dest)
+			}
+		}
+	}
+
+	result := make([]string, 0, len(closureSet))
+	for state := range closureSet {
+		result = append(result, state)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func makeStateName(states []string) string {
+	return strings.Join(states, "_")
+}
@@ -0,0 +1,162 @@
+package symbolic
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type minimizer struct {
+	dfa           *DFA
+	minterms      []Predicate
+	partitions    map[string]int
+	numPartitions int
+}
+
+func Minimize(dfa *DFA) *DFA {
+	m := &minimizer{dfa: removeUnreachableStates(dfa)}
+	if len(m.dfa.States) <= 1 {
+		return m.dfa
+	}
+
+	var predicates []Predicate
+	for _, edges := range m.dfa.Transitions {
+		for _, edge := range edges {
+			predicates = append(predicates, edge.Predicate)
+		}
+	}
+	m.minterms = Minterms(predicates)
+
+	m.initializePartitions()
+	m.refinePartitions()
+	return m.buildMinimizedDFA()
+}
+
+func (m *minimizer) initializePartitions() {
+	m.partitions = make(map[string]int)
+	hasAccepting, hasNonAccepting := false, false
+	for _, state := range m.dfa.States {
+		if m.dfa.AcceptingStates[state] {
+			m.partitions[state] = 1
+			hasAccepting = true
+		} else {
+			m.partitions[state] = 0
+			hasNonAccepting = true
+		}
+	}
+
+	m.numPartitions = 1
+	if hasAccepting && hasNonAccepting {
+		m.numPartitions = 2
+	}
+}
+
+func (m *minimizer) refinePartitions() {
+	for m.numPartitions < len(m.dfa.States) {
+		newPartitions := make(map[string]int)
+		groupIDs := make(map[string]int)
+		for _, state := range m.dfa.States {
+			groupKey := fmt.Sprintf("%d-%s", m.partitions[state], m.stateSignature(state))
+			if _, exists := groupIDs[groupKey]; !exists {
+				groupIDs[groupKey] = len(groupIDs)
+			}
+			newPartitions[state] = groupIDs[groupKey]
+		}
+		if len(groupIDs) == m.numPartitions {
+			break
+		}
+		m.partitions = newPartitions
+		m.numPartitions = len(groupIDs)
+	}
+}
+
+func (m *minimizer) stateSignature(state string) string {
+	var signature strings.Builder
+	for _, minterm := range m.minterms {
+		partitionID := -1
+		if dest, ok := m.dfa.Next(state, minterm[0].Lo); ok {
+			partitionID = m.partitions[dest]
+		}
+		signature.WriteString(fmt.Sprintf("%d-", partitionID))
+	}
+	return signature.String()
+}
+
+func (m *minimizer) buildMinimizedDFA() *DFA {
+	groups := make(map[int][]string)
+	for _, state := range m.dfa.States {
+		groups[m.partitions[state]] = append(groups[m.partitions[state]], state)
+	}
+
+	ordered := make([][]string, 0, len(groups))
+	for _, states := range groups {
+		sort.Strings(states)
+		ordered = append(ordered, states)
+	}
+	startPartition := m.partitions[m.dfa.StartState]
+	sort.Slice(ordered, func(i, j int) bool {
+		isStartI := m.partitions[ordered[i][0]] == startPartition
+		isStartJ := m.partitions[ordered[j][0]] == startPartition
+		if isStartI != isStartJ {
+			return isStartI
+		}
+		return ordered[i][0] < ordered[j][0]
+	})
+
+	stateMap := make(map[string]string)
+	for i, states := range ordered {
+		for _, state := range states {
+			stateMap[state] = fmt.Sprintf("S%d", i)
+		}
+	}
+
+	minDFA := NewDFA()
+	minDFA.StartState = stateMap[m.dfa.StartState]
+	for _, states := range ordered {
+		representative := states[0]
+		newState := stateMap[representative]
+		minDFA.States = append(minDFA.States, newState)
+		if m.dfa.AcceptingStates[representative] {
+			minDFA.AcceptingStates[newState] = true
+		}
+
+		var edges []Edge
+		for _, edge := range m.dfa.Transitions[representative] {
+			edges = append(edges, Edge{Predicate: edge.Predicate, To: stateMap[edge.To]})
+		}
+		if len(edges) > 0 {
+			minDFA.Transitions[newState] = mergeEdges(edges)
+		}
+	}
+	sort.Strings(minDFA.States)
+	return minDFA
+}
+
+func removeUnreachableStates(dfa *DFA) *DFA {
+	reachable := map[string]bool{dfa.StartState: true}
+	queue := []string{dfa.StartState}
+	for head := 0; head < len(queue); head++ {
+		for _, edge := range dfa.Transitions[queue[head]] {
+			if !reachable[edge.To] {
+				reachable[edge.To] = true
+				queue = append(queue, edge.To)
+			}
+		}
+	}
+
+	result := NewDFA()
+	result.StartState = dfa.StartState
+	for _, state := range dfa.States {
+		if !reachable[state] {
+			continue
+		}
+		result.States = append(result.States, state)
+		if dfa.AcceptingStates[state] {
+			result.AcceptingStates[state] = true
+		}
+		if edges, ok := dfa.Transitions[state]; ok {
+			result.Transitions[state] = edges
+		}
+	}
+	return result
+}
@@ -0,0 +1,53 @@
+package symbolic
+
+import (
+	"sort"
+	"strings"
+)
+
+func Minterms(predicates []Predicate) []Predicate {
+	boundarySet := make(map[rune]bool)
+	for _, p := range predicates {
+		for _, r := range p {
+			boundarySet[r.Lo] = true
+			boundarySet[r.Hi+1] = true
+		}
+	}
+	boundaries := make([]rune, 0, len(boundarySet))
+	for b := range boundarySet {
+		boundaries = append(boundaries, b)
+	}
+	sort.Slice(boundaries, func(i, j int) bool { return boundaries[i] < boundaries[j] })
+
+	groups := make(map[string][]Range)
+	var order []string
+	for i := 0; i+1 < len(boundaries); i++ {
+		interval := Range{Lo: boundaries[i], Hi: boundaries[i+1] - 1}
+		signature := membershipSignature(predicates, interval.Lo)
+		if !strings.ContainsRune(signature, '1') {
+			continue
+		}
+		if _, exists := groups[signature]; !exists {
+			order = append(order, signature)
+		}
+		groups[signature] = append(groups[signature], interval)
+	}
+
+	minterms := make([]Predicate, 0, len(order))
+	for _, signature := range order {
+		minterms = append(minterms, NewPredicate(groups[signature]...))
+	}
+	return minterms
+}
+
+func membershipSignature(predicates []Predicate, r rune) string {
+	var signature strings.Builder
+	for _, p := range predicates {
+		if p.Contains(r) {
+			signature.WriteByte('1')
+		} else {
+			signature.WriteByte('0')
+		}
+	}
+	return signature.String()
+}
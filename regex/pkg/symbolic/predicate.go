@@ -0,0 +1,161 @@
+package symbolic
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type Range struct {
+	Lo rune
+	Hi rune
+}
+
+type Predicate []Range
+
+func NewPredicate(ranges ...Range) Predicate {
+	sorted := make([]Range, 0, len(ranges))
+	for _, r := range ranges {
+		if r.Lo <= r.Hi {
+			sorted = append(sorted, r)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Lo < sorted[j].Lo })
+
+	var p Predicate
+	for _, r := range sorted {
+		if n := len(p); n > 0 && r.Lo <= p[n-1].Hi+1 {
+			if r.Hi > p[n-1].Hi {
+				p[n-1].Hi = r.Hi
+			}
+			continue
+		}
+		p = append(p, r)
+	}
+	return p
+}
+
+func Rune(r rune) Predicate {
+	return Predicate{{Lo: r, Hi: r}}
+}
+
+func FromRangeTable(table *unicode.RangeTable) Predicate {
+	var ranges []Range
+	for _, r := range table.R16 {
+		ranges = appendStrideRanges(ranges, rune(r.Lo), rune(r.Hi), rune(r.Stride))
+	}
+	for _, r := range table.R32 {
+		ranges = appendStrideRanges(ranges, rune(r.Lo), rune(r.Hi), rune(r.Stride))
+	}
+	return NewPredicate(ranges...)
+}
+
+func Any() Predicate {
+	return Predicate{{Lo: 0, Hi: utf8.MaxRune}}
+}
+
+func (p Predicate) IsEmpty() bool {
+	return len(p) == 0
+}
+
+func (p Predicate) Contains(r rune) bool {
+	i := sort.Search(len(p), func(i int) bool { return p[i].Hi >= r })
+	return i < len(p) && p[i].Lo <= r
+}
+
+func (p Predicate) Union(other Predicate) Predicate {
+	ranges := make([]Range, 0, len(p)+len(other))
+	ranges = append(ranges, p...)
+	ranges = append(ranges, other...)
+	return NewPredicate(ranges...)
+}
+
+func (p Predicate) Intersect(other Predicate) Predicate {
+	var result Predicate
+	i, j := 0, 0
+	for i < len(p) && j < len(other) {
+		lo := max(p[i].Lo, other[j].Lo)
+		hi := min(p[i].Hi, other[j].Hi)
+		if lo <= hi {
+			result = append(result, Range{Lo: lo, Hi: hi})
+		}
+		if p[i].Hi < other[j].Hi {
+			i++
+		} else {
+			j++
+		}
+	}
+	return result
+}
+
+func (p Predicate) Complement() Predicate {
+	var result Predicate
+	next := rune(0)
+	for _, r := range p {
+		if r.Lo > next {
+			result = append(result, Range{Lo: next, Hi: r.Lo - 1})
+		}
+		next = r.Hi + 1
+	}
+	if next <= utf8.MaxRune {
+		result = append(result, Range{Lo: next, Hi: utf8.MaxRune})
+	}
+	return result
+}
+
+func (p Predicate) Difference(other Predicate) Predicate {
+	return p.Intersect(other.Complement())
+}
+
+func (p Predicate) Equal(other Predicate) bool {
+	if len(p) != len(other) {
+		return false
+	}
+	for i := range p {
+		if p[i] != other[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (p Predicate) Size() int {
+	size := 0
+	for _, r := range p {
+		size += int(r.Hi-r.Lo) + 1
+	}
+	return size
+}
+
+func (p Predicate) String() string {
+	var builder strings.Builder
+	builder.WriteString("[")
+	for _, r := range p {
+		builder.WriteString(formatRune(r.Lo))
+		if r.Hi != r.Lo {
+			builder.WriteString("-")
+			builder.WriteString(formatRune(r.Hi))
+		}
+	}
+	builder.WriteString("]")
+	return builder.String()
+}
+
+func appendStrideRanges(ranges []Range, lo, hi, stride rune) []Range {
+	if stride == 1 {
+		return append(ranges, Range{Lo: lo, Hi: hi})
+	}
+	for r := lo; r <= hi; r += stride {
+		ranges = append(ranges, Range{Lo: r, Hi: r})
+	}
+	return ranges
+}
+
+func formatRune(r rune) string {
+	if r != ' ' && unicode.IsPrint(r) && !strings.ContainsRune(`[]-\"`, r) {
+		return string(r)
+	}
+	return fmt.Sprintf(`\u%04X`, r)
+}
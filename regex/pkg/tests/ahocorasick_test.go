@@ -0,0 +1,47 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"regex/pkg/ahocorasick"
+	"regex/pkg/matcher"
+	"regex/pkg/minimizer"
+)
+
+func TestAhoCorasickSearch(t *testing.T) {
+	keywords, err := ahocorasick.ParseDictionary(strings.NewReader("# pronouns\nhe\nshe\n\nhis\nhers\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"he", "she", "his", "hers"}, keywords)
+
+	a := ahocorasick.New(keywords)
+	assert.Equal(t, []ahocorasick.Match{
+		{Keyword: "she", Offset: 1},
+		{Keyword: "he", Offset: 2},
+		{Keyword: "hers", Offset: 2},
+	}, a.Search("ushers"))
+
+	assert.Equal(t, []ahocorasick.Match{
+		{Keyword: "he", Offset: 9},
+		{Keyword: "his", Offset: 12},
+	}, ahocorasick.New([]string{"he", "his"}).Search("ёжик he his"))
+}
+
+func TestAhoCorasickDFAMatchesRegex(t *testing.T) {
+	a := ahocorasick.New([]string{"ab", "bc", "abc", "cab"})
+	dfa := a.ToDFA()
+	for _, state := range dfa.States {
+		assert.Len(t, dfa.Transitions[state], len(dfa.Alphabet), "state %s must be complete", state)
+	}
+
+	acMatcher := matcher.NewDFAMatcher(dfa)
+	regexMatcher := matcher.NewDFAMatcher(buildMinimizedDFA(t, `(a|b|c)*(ab|bc|abc|cab)`))
+	for _, input := range allStrings([]string{"a", "b", "c"}, 6) {
+		assert.Equal(t, regexMatcher.Match(input), acMatcher.Match(input), "input %q", input)
+	}
+
+	minimized := minimizer.NewMinimizer(dfa).Minimize()
+	assert.Len(t, minimized.States, len(buildMinimizedDFA(t, `(a|b|c)*(ab|bc|abc|cab)`).States))
+}
@@ -0,0 +1,99 @@
+package tests
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+
+	"regex/pkg/bytelevel"
+	"regex/pkg/determinizer"
+	"regex/pkg/matcher"
+	"regex/pkg/minimizer"
+)
+
+func sequenceMatches(sequence bytelevel.Sequence, encoded []byte) bool {
+	if len(sequence) != len(encoded) {
+		return false
+	}
+	for i, byteRange := range sequence {
+		if encoded[i] < byteRange.Lo || encoded[i] > byteRange.Hi {
+			return false
+		}
+	}
+	return true
+}
+
+func TestUTF8Sequences(t *testing.T) {
+	testCases := []struct {
+		lo rune
+		hi rune
+	}{
+		{'a', 'z'},
+		{0x00, 0x7F},
+		{0x70, 0x900},
+		{'а', 'я'},
+		{0xD000, 0xE100},
+		{0xFFF0, 0x10100},
+		{0x00, utf8.MaxRune},
+	}
+
+	for _, tc := range testCases {
+		sequences := bytelevel.Sequences(tc.lo, tc.hi)
+		for r := tc.lo - 300; r <= tc.hi+300; r += 1 + (tc.hi-tc.lo)/4096 {
+			if r < 0 || r > utf8.MaxRune || (r >= 0xD800 && r <= 0xDFFF) {
+				continue
+			}
+			encoded := utf8.AppendRune(nil, r)
+			matches := 0
+			for _, sequence := range sequences {
+				if sequenceMatches(sequence, encoded) {
+					matches++
+				}
+			}
+			if r >= tc.lo && r <= tc.hi {
+				assert.Equal(t, 1, matches, "rune %U in [%U, %U]", r, tc.lo, tc.hi)
+			} else {
+				assert.Equal(t, 0, matches, "rune %U outside [%U, %U]", r, tc.lo, tc.hi)
+			}
+		}
+	}
+}
+
+func TestByteLevelDFAAgreesWithRuneDFA(t *testing.T) {
+	regexes := []string{
+		`(a|b)*abb`,
+		`(а|б|в)*где`,
+		`x(日|本|語)*y`,
+	}
+	inputs := []string{"", "abb", "aabb", "абвгде", "где", "гд", "xy", "x日本語y", "x日本y", "x日z"}
+
+	for _, r := range regexes {
+		t.Run(r, func(t *testing.T) {
+			byteNFA, err := bytelevel.Lower(buildNFA(t, r))
+			if err != nil {
+				t.Fatalf("Lowering failed: %v", err)
+			}
+			assert.LessOrEqual(t, len(byteNFA.Alphabet), 256)
+
+			byteDFA := minimizer.NewMinimizer(determinizer.NewDeterminizer(byteNFA).Run()).Minimize()
+			byteMatcher, err := bytelevel.NewByteMatcher(byteDFA)
+			if err != nil {
+				t.Fatalf("Byte matcher construction failed: %v", err)
+			}
+
+			runeMatcher := matcher.NewDFAMatcher(buildMinimizedDFA(t, r))
+			for _, input := range inputs {
+				assert.Equal(t, runeMatcher.Match(input), byteMatcher.Match([]byte(input)), "input %q", input)
+			}
+		})
+	}
+}
+
+func TestByteSymbolRoundTrip(t *testing.T) {
+	for b := 0; b < 256; b++ {
+		parsed, err := bytelevel.ParseByteSymbol(bytelevel.ByteSymbol(byte(b)))
+		assert.NoError(t, err)
+		assert.Equal(t, byte(b), parsed)
+	}
+}
@@ -0,0 +1,114 @@
+package tests
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"regex/pkg/compiled"
+	"regex/pkg/determinizer"
+	"regex/pkg/matcher"
+	"regex/pkg/minimizer"
+	"regex/pkg/simulator"
+)
+
+var compiledRegexes = []string{
+	`(ab*a|b)*`,
+	`(a*|b*)*`,
+	`(a*c*a*)*b(a*b*c*)*`,
+	`ab*((a|b*)df(b|a*))((a|b*)df(b|a*))*`,
+	`(ab*a|b)(ab*a|b)*|abb(ab)*|ε`,
+	`cac*(ba)*|(ca)*cb*`,
+	`a*(a|b)*a|b*|(c|b)*b|c*(c|a)*c`,
+	`(a|b)*a(a|b)(a|b)(a|b)(a|b)(a|b)`,
+}
+
+func TestCompiledDeterminizerAgreesWithStringBased(t *testing.T) {
+	for _, r := range compiledRegexes {
+		t.Run(r, func(t *testing.T) {
+			expected := determinizer.NewDeterminizer(buildNFA(t, r)).Run()
+			actual := determinizer.NewDeterminizer(buildNFA(t, r)).RunCompiled()
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
+
+func TestCompiledMinimizerAgreesWithStringBased(t *testing.T) {
+	for _, r := range compiledRegexes {
+		t.Run(r, func(t *testing.T) {
+			expected := minimizer.NewMinimizer(determinizer.NewDeterminizer(buildNFA(t, r)).Run()).Minimize()
+			actual := minimizer.NewMinimizer(determinizer.NewDeterminizer(buildNFA(t, r)).Run()).MinimizeCompiled()
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
+
+func TestCompiledPathOnLargeInput(t *testing.T) {
+	r := `(a|b)*a` + strings.Repeat(`(a|b)`, 9)
+	dfa := minimizer.NewMinimizer(determinizer.NewDeterminizer(buildNFA(t, r)).Run()).Minimize()
+	assert.Len(t, dfa.States, 1024)
+
+	c := compiled.Compile(dfa)
+	assert.True(t, c.Match("bbaaaaaaaaaa"))
+	assert.False(t, c.Match("bbbaaaaaaaaa"))
+
+	r = strings.Repeat(`(ab|ac)`, 30)
+	nfa := buildNFA(t, r)
+	assert.GreaterOrEqual(t, len(nfa.States), 256)
+
+	sim := simulator.NewSimulator(nfa)
+	c = compiled.Compile(minimizer.NewMinimizer(determinizer.NewDeterminizer(nfa).Run()).Minimize())
+	inputs := []string{strings.Repeat("ab", 30), strings.Repeat("ac", 30), strings.Repeat("ab", 29), strings.Repeat("ad", 30)}
+	for _, input := range inputs {
+		assert.Equal(t, sim.Match(input), c.Match(input), "input %q", input)
+	}
+}
+
+func TestCompiledThresholdSelectsEquivalentPaths(t *testing.T) {
+	regexes := append([]string{strings.Repeat(`(ab|ac)`, 30)}, compiledRegexes...)
+	for _, r := range regexes {
+		t.Run(r, func(t *testing.T) {
+			compiledPath := determinizer.Options{CompiledThreshold: 1}
+			stringPath := determinizer.Options{CompiledThreshold: math.MaxInt}
+			expected := determinizer.NewDeterminizerWithOptions(buildNFA(t, r), stringPath).Run()
+			actual := determinizer.NewDeterminizerWithOptions(buildNFA(t, r), compiledPath).Run()
+			assert.Equal(t, expected, actual)
+
+			expected = minimizer.NewMinimizerWithOptions(expected, minimizer.Options{CompiledThreshold: math.MaxInt}).Minimize()
+			actual = minimizer.NewMinimizerWithOptions(actual, minimizer.Options{CompiledThreshold: 1}).Minimize()
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
+
+func TestCompiledDFAMatchAndRoundTrip(t *testing.T) {
+	for _, r := range compiledRegexes {
+		t.Run(r, func(t *testing.T) {
+			dfa := buildMinimizedDFA(t, r)
+			c := compiled.Compile(dfa)
+			dfaMatcher := matcher.NewDFAMatcher(dfa)
+			for _, input := range allStrings([]string{"a", "b", "c", "d", "f"}, 4) {
+				assert.Equal(t, dfaMatcher.Match(input), c.Match(input), "input %q", input)
+			}
+
+			roundTrip := c.ToModel()
+			assert.Equal(t, dfa.States, roundTrip.States)
+			assert.Equal(t, dfa.StartState, roundTrip.StartState)
+			assert.Equal(t, dfa.AcceptingStates, roundTrip.AcceptingStates)
+			for state, transitions := range roundTrip.Transitions {
+				assert.Equal(t, dfa.Transitions[state], transitions)
+			}
+		})
+	}
+}
+
+func TestCompiledMatchDoesNotAllocate(t *testing.T) {
+	c := compiled.Compile(buildMinimizedDFA(t, `(a|b)*abb`))
+	input := strings.Repeat("ab", 100) + "abb"
+	allocs := testing.AllocsPerRun(100, func() {
+		c.Match(input)
+	})
+	assert.Zero(t, allocs)
+}
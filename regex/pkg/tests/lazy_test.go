@@ -0,0 +1,59 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"regex/pkg/determinizer"
+	"regex/pkg/matcher"
+)
+
+func TestLazyDFAAgreesWithDFA(t *testing.T) {
+	regexes := []string{
+		`(a|b)*abb`,
+		`(a*|b*|b)*b`,
+		`cac*(ba)*|(ca)*cb*`,
+		`(a|b)*a(a|b)(a|b)(a|b)`,
+	}
+	inputs := []string{"", "a", "b", "abb", "aabb", "cab", "cacc", "cbbb", "abaa", "babbb", "aaaabbbb", "bbbabbbab"}
+
+	for _, r := range regexes {
+		t.Run(r, func(t *testing.T) {
+			lazy := determinizer.NewLazyDFA(buildNFA(t, r), determinizer.DefaultLazyCacheSize)
+			dfaMatcher := matcher.NewDFAMatcher(buildMinimizedDFA(t, r))
+			for _, input := range inputs {
+				assert.Equal(t, dfaMatcher.Match(input), lazy.Match(input), "input %q", input)
+			}
+			assert.Zero(t, lazy.Flushes())
+		})
+	}
+}
+
+func TestLazyDFAFlushesCache(t *testing.T) {
+	r := `(a|b)*a(a|b)(a|b)(a|b)`
+	lazy := determinizer.NewLazyDFA(buildNFA(t, r), 512)
+	dfaMatcher := matcher.NewDFAMatcher(buildMinimizedDFA(t, r))
+
+	inputs := []string{"abababababbbaaab", "bbbbbbbbabbb", "aaaaaaaaaaaaaaaa", "abbbabbbabbbb"}
+	for _, input := range inputs {
+		assert.Equal(t, dfaMatcher.Match(input), lazy.Match(input), "input %q", input)
+	}
+	assert.Positive(t, lazy.Flushes())
+}
+
+func TestLazyDFAFlushKeepsOneStatePerSubset(t *testing.T) {
+	r := `x(a|b)*|(ab)*`
+	nfa := buildNFA(t, r)
+	subsets := len(determinizer.NewDeterminizer(nfa).Run().States)
+	dfaMatcher := matcher.NewDFAMatcher(buildMinimizedDFA(t, r))
+
+	for _, cacheSize := range []int{160, 200, 256, 320} {
+		lazy := determinizer.NewLazyDFA(buildNFA(t, r), cacheSize)
+		for _, input := range []string{"x" + strings.Repeat("a", 40), strings.Repeat("ab", 20), "xabba", "aba"} {
+			assert.Equal(t, dfaMatcher.Match(input), lazy.Match(input), "cache %d, input %q", cacheSize, input)
+			assert.LessOrEqual(t, lazy.CachedStates(), subsets, "cache %d", cacheSize)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"regex/pkg/determinizer"
+	"regex/pkg/levenshtein"
+	"regex/pkg/matcher"
+	"regex/pkg/minimizer"
+)
+
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr := make([]int, len(rb)+1)
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev = curr
+	}
+	return prev[len(rb)]
+}
+
+func allStrings(alphabet []string, maxLength int) []string {
+	result := []string{""}
+	layer := []string{""}
+	for length := 1; length <= maxLength; length++ {
+		var next []string
+		for _, prefix := range layer {
+			for _, symbol := range alphabet {
+				next = append(next, prefix+symbol)
+			}
+		}
+		result = append(result, next...)
+		layer = next
+	}
+	return result
+}
+
+func TestLevenshteinDFAForWord(t *testing.T) {
+	alphabet := []string{"a", "b", "c", "x"}
+	testCases := []struct {
+		word     string
+		maxEdits int
+	}{
+		{"abc", 0},
+		{"abc", 1},
+		{"abc", 2},
+		{"", 1},
+		{"abca", 1},
+	}
+
+	for _, tc := range testCases {
+		dfaMatcher := matcher.NewDFAMatcher(levenshtein.NewDFA(tc.word, tc.maxEdits, alphabet))
+		for _, input := range allStrings(alphabet, 5) {
+			expected := editDistance(tc.word, input) <= tc.maxEdits
+			assert.Equal(t, expected, dfaMatcher.Match(input), "word %q, k=%d, input %q", tc.word, tc.maxEdits, input)
+		}
+	}
+}
+
+func TestLevenshteinExpandPatternLanguage(t *testing.T) {
+	alphabet := []string{"a", "b"}
+	language := []string{"", "ab", "abab", "ababab"}
+
+	nfa := levenshtein.Expand(buildNFA(t, `(ab)*`), 1, alphabet)
+	dfa := minimizer.NewMinimizer(determinizer.NewDeterminizer(nfa).Run()).Minimize()
+	dfaMatcher := matcher.NewDFAMatcher(dfa)
+
+	for _, input := range allStrings(alphabet, 5) {
+		expected := false
+		for _, word := range language {
+			if editDistance(word, input) <= 1 {
+				expected = true
+				break
+			}
+		}
+		assert.Equal(t, expected, dfaMatcher.Match(input), "input %q", input)
+	}
+}
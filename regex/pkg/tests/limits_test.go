@@ -0,0 +1,50 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"regex/pkg/determinizer"
+)
+
+const explodingRegex = `(a|b)*a(a|b)(a|b)(a|b)(a|b)(a|b)(a|b)(a|b)(a|b)`
+
+func TestRunContextStateLimit(t *testing.T) {
+	d := determinizer.NewDeterminizer(buildNFA(t, explodingRegex))
+	dfa, err := d.RunContext(context.Background(), determinizer.Limits{MaxStates: 50})
+
+	assert.Nil(t, dfa)
+	var limitErr *determinizer.LimitError
+	if assert.True(t, errors.As(err, &limitErr)) {
+		assert.ErrorIs(t, err, determinizer.ErrStateLimit)
+		assert.Greater(t, limitErr.Stats.States, 50)
+		assert.Greater(t, limitErr.Stats.Processed, 0)
+	}
+}
+
+func TestRunCompiledContextStateLimit(t *testing.T) {
+	d := determinizer.NewDeterminizer(buildNFA(t, strings.Repeat("(ab|ac)", 30)+explodingRegex))
+	_, err := d.RunCompiledContext(context.Background(), determinizer.Limits{MaxStates: 50})
+
+	assert.ErrorIs(t, err, determinizer.ErrStateLimit)
+}
+
+func TestRunContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := determinizer.NewDeterminizer(buildNFA(t, explodingRegex)).RunContext(ctx, determinizer.Limits{})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRunContextWithinLimits(t *testing.T) {
+	expected := determinizer.NewDeterminizer(buildNFA(t, `(a|b)*abb`)).Run()
+	actual, err := determinizer.NewDeterminizer(buildNFA(t, `(a|b)*abb`)).RunContext(context.Background(), determinizer.Limits{MaxStates: 100})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
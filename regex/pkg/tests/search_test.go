@@ -0,0 +1,79 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"fsm/pkg/automaton"
+	"regex/pkg/search"
+)
+
+func findAll(t *testing.T, regexInput, text string, bufferSize int) ([]search.Match, error) {
+	t.Helper()
+	finder := search.NewFinder(buildMinimizedDFA(t, regexInput), bufferSize)
+
+	var matches []search.Match
+	err := finder.Find(strings.NewReader(text), func(m search.Match) error {
+		matches = append(matches, m)
+		return nil
+	})
+	return matches, err
+}
+
+func TestFindLeftmostLongest(t *testing.T) {
+	text := "abb xx aabb\nfoo babbabb\nабв ab"
+	expected := []search.Match{
+		{Offset: 0, Line: 1, Column: 1, Text: "abb"},
+		{Offset: 7, Line: 1, Column: 8, Text: "aabb"},
+		{Offset: 16, Line: 2, Column: 5, Text: "babbabb"},
+	}
+
+	for _, bufferSize := range []int{8, 16, search.DefaultBufferSize} {
+		matches, err := findAll(t, `(a|b)*abb`, text, bufferSize)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, matches, "buffer size %d", bufferSize)
+	}
+}
+
+func TestFindReportsRuneColumns(t *testing.T) {
+	matches, err := findAll(t, `в(а|б)*`, "ааб вааб\nбв", 16)
+	assert.NoError(t, err)
+	assert.Equal(t, []search.Match{
+		{Offset: 7, Line: 1, Column: 5, Text: "вааб"},
+		{Offset: 18, Line: 2, Column: 2, Text: "в"},
+	}, matches)
+}
+
+func TestFindSkipsEmptyMatches(t *testing.T) {
+	matches, err := findAll(t, `a*`, "baab", 8)
+	assert.NoError(t, err)
+	assert.Equal(t, []search.Match{{Offset: 1, Line: 1, Column: 2, Text: "aa"}}, matches)
+}
+
+func TestFindFailsWhenMatchExceedsBuffer(t *testing.T) {
+	_, err := findAll(t, `a*`, "b"+strings.Repeat("a", 64), 16)
+	assert.ErrorContains(t, err, "offset 1 ")
+}
+
+func TestFindFailsWhenLiveAttemptExceedsBuffer(t *testing.T) {
+	_, err := findAll(t, `a*b`, strings.Repeat("a", 64), 16)
+	assert.ErrorContains(t, err, "offset 0 ")
+
+	matches, err := findAll(t, `xa*b|ab`, "x"+strings.Repeat("a", 20)+"b", 16)
+	assert.ErrorContains(t, err, "offset 0 ")
+	assert.Empty(t, matches)
+}
+
+func TestFindStopsDeadAttemptsAtBufferBoundary(t *testing.T) {
+	finder := search.NewFinder(automaton.Complete(buildMinimizedDFA(t, `ab`)), 16)
+
+	var matches []search.Match
+	err := finder.Find(strings.NewReader("ab"+strings.Repeat("b", 40)), func(m search.Match) error {
+		matches = append(matches, m)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []search.Match{{Offset: 0, Line: 1, Column: 1, Text: "ab"}}, matches)
+}
@@ -0,0 +1,80 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"regex/pkg/determinizer"
+	"regex/pkg/matcher"
+	"regex/pkg/minimizer"
+	"regex/pkg/model"
+	"regex/pkg/postfix"
+	"regex/pkg/regex"
+	"regex/pkg/simulator"
+)
+
+func buildNFA(t *testing.T, regexInput string) *model.NFA {
+	t.Helper()
+	postfixStr, err := postfix.ToPostfix(regexInput)
+	if err != nil {
+		t.Fatalf("Postfix conversion failed: %v", err)
+	}
+
+	nfa, err := regex.NewConverter().ConvertToNFA(postfixStr)
+	if err != nil {
+		t.Fatalf("NFA conversion failed: %v", err)
+	}
+	return nfa
+}
+
+func buildMinimizedDFA(t *testing.T, regexInput string) *model.DFA {
+	t.Helper()
+	dfa := determinizer.NewDeterminizer(buildNFA(t, regexInput)).Run()
+	return minimizer.NewMinimizer(dfa).Minimize()
+}
+
+func TestSimulatorMatch(t *testing.T) {
+	testCases := []struct {
+		regex    string
+		accepted []string
+		rejected []string
+	}{
+		{`(a|b)*abb`, []string{"abb", "aabb", "babababb"}, []string{"", "ab", "abba", "abc"}},
+		{`(ab*a|b)*`, []string{"", "aa", "abba", "bbb"}, []string{"a", "bab", "abaa"}},
+		{`ab*b*a*b`, []string{"ab", "abbbab", "abaab"}, []string{"a", "abba", "b"}},
+		{`(ab*a|b)(ab*a|b)*|abb(ab)*|ε`, []string{"", "abb", "abbab", "b"}, []string{"a", "aaa"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.regex, func(t *testing.T) {
+			sim := simulator.NewSimulator(buildNFA(t, tc.regex))
+			for _, input := range tc.accepted {
+				assert.True(t, sim.Match(input), "expected %q to be accepted", input)
+			}
+			for _, input := range tc.rejected {
+				assert.False(t, sim.Match(input), "expected %q to be rejected", input)
+			}
+		})
+	}
+}
+
+func TestSimulatorAgreesWithDFA(t *testing.T) {
+	regexes := []string{
+		`(a*|b*|b)*b`,
+		`cac*(ba)*|(ca)*cb*`,
+		`a*(a|b)*a|b*|(c|b)*b|c*(c|a)*c`,
+		`ab*((a|b*)df(b|a*))((a|b*)df(b|a*))*`,
+	}
+	inputs := []string{"", "a", "b", "c", "ab", "ba", "cab", "cacc", "cbbb", "abdf", "abdfbdfa", "cacbaba", "aca"}
+
+	for _, r := range regexes {
+		t.Run(r, func(t *testing.T) {
+			sim := simulator.NewSimulator(buildNFA(t, r))
+			dfaMatcher := matcher.NewDFAMatcher(buildMinimizedDFA(t, r))
+			for _, input := range inputs {
+				assert.Equal(t, dfaMatcher.Match(input), sim.Match(input), "input %q", input)
+			}
+		})
+	}
+}
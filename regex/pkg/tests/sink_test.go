@@ -0,0 +1,55 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"fsm/pkg/automaton"
+	"regex/pkg/compiled"
+	"regex/pkg/determinizer"
+	"regex/pkg/minimizer"
+)
+
+func TestCompleteAndStripSinks(t *testing.T) {
+	for _, r := range compiledRegexes {
+		t.Run(r, func(t *testing.T) {
+			dfa := minimizer.NewMinimizer(determinizer.NewDeterminizer(buildNFA(t, r)).Run()).Minimize()
+			complete := automaton.Complete(dfa)
+
+			assert.True(t, automaton.IsComplete(complete))
+			assert.LessOrEqual(t, len(automaton.SinkStates(complete)), 1)
+			assert.Equal(t, dfa, automaton.StripSinks(complete))
+
+			partialMatcher := compiled.Compile(dfa)
+			completeMatcher := compiled.Compile(complete)
+			for _, input := range []string{"", "a", "ab", "abb", "ba", "cac", "cb", "adfb", "abdfbdf", "ccc"} {
+				assert.Equal(t, partialMatcher.Match(input), completeMatcher.Match(input), input)
+			}
+		})
+	}
+}
+
+func TestMinimizeKeepsSinkOfCompleteDFA(t *testing.T) {
+	dfa := minimizer.NewMinimizer(determinizer.NewDeterminizer(buildNFA(t, "ab")).Run()).Minimize()
+	complete := minimizer.NewMinimizer(automaton.Complete(dfa)).Minimize()
+
+	assert.True(t, automaton.IsComplete(complete))
+	assert.Len(t, complete.States, len(dfa.States)+1)
+	assert.Len(t, automaton.SinkStates(complete), 1)
+}
+
+func TestCompleteDeterminization(t *testing.T) {
+	options := determinizer.Options{Complete: true}
+	for _, r := range compiledRegexes {
+		t.Run(r, func(t *testing.T) {
+			partial := determinizer.NewDeterminizer(buildNFA(t, r)).Run()
+			complete := determinizer.NewDeterminizerWithOptions(buildNFA(t, r), options).Run()
+			compiledComplete := determinizer.NewDeterminizerWithOptions(buildNFA(t, r), options).RunCompiled()
+
+			assert.True(t, automaton.IsComplete(complete))
+			assert.Equal(t, complete, compiledComplete)
+			assert.Equal(t, automaton.Complete(partial), complete)
+		})
+	}
+}
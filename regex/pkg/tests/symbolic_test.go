@@ -0,0 +1,98 @@
+package tests
+
+import (
+	"testing"
+	"unicode"
+
+	"github.com/stretchr/testify/assert"
+
+	"regex/pkg/matcher"
+	"regex/pkg/symbolic"
+)
+
+func TestPredicateOperations(t *testing.T) {
+	lower := symbolic.NewPredicate(symbolic.Range{Lo: 'a', Hi: 'z'})
+	middle := symbolic.NewPredicate(symbolic.Range{Lo: 'm', Hi: 'p'}, symbolic.Range{Lo: '0', Hi: '9'})
+
+	assert.Equal(t, "[0-9a-z]", lower.Union(middle).String())
+	assert.Equal(t, "[m-p]", lower.Intersect(middle).String())
+	assert.Equal(t, "[a-lq-z]", lower.Difference(middle).String())
+	assert.True(t, lower.Complement().Complement().Equal(lower))
+	assert.True(t, lower.Intersect(lower.Complement()).IsEmpty())
+	assert.Equal(t, 26, lower.Size())
+	assert.True(t, middle.Contains('5'))
+	assert.False(t, middle.Contains('q'))
+}
+
+func TestMinterms(t *testing.T) {
+	lower := symbolic.NewPredicate(symbolic.Range{Lo: 'a', Hi: 'z'})
+	middle := symbolic.NewPredicate(symbolic.Range{Lo: 'm', Hi: 'p'})
+	digits := symbolic.NewPredicate(symbolic.Range{Lo: '0', Hi: '9'})
+
+	minterms := symbolic.Minterms([]symbolic.Predicate{lower, middle, digits})
+	actual := make([]string, 0, len(minterms))
+	for _, minterm := range minterms {
+		actual = append(actual, minterm.String())
+	}
+	assert.ElementsMatch(t, []string{"[0-9]", "[a-lq-z]", "[m-p]"}, actual)
+}
+
+func TestSymbolicPipelineAgreesWithExplicit(t *testing.T) {
+	regexes := []string{
+		`(a|b)*abb`,
+		`cac*(ba)*|(ca)*cb*`,
+		`a*(a|b)*a|b*|(c|b)*b|c*(c|a)*c`,
+	}
+	inputs := []string{"", "a", "b", "c", "abb", "aabb", "cab", "cacc", "cbbb", "cacbaba", "aca", "bcb"}
+
+	for _, r := range regexes {
+		t.Run(r, func(t *testing.T) {
+			symbolicNFA, err := symbolic.FromNFA(buildNFA(t, r))
+			if err != nil {
+				t.Fatalf("Conversion to symbolic NFA failed: %v", err)
+			}
+			symbolicDFA := symbolic.Minimize(symbolic.Determinize(symbolicNFA))
+			explicitDFA := buildMinimizedDFA(t, r)
+
+			assert.Equal(t, len(explicitDFA.States), len(symbolicDFA.States))
+
+			convertedDFA, err := symbolic.ToDFA(symbolicDFA, 256)
+			if err != nil {
+				t.Fatalf("Conversion to explicit DFA failed: %v", err)
+			}
+			explicitMatcher := matcher.NewDFAMatcher(explicitDFA)
+			convertedMatcher := matcher.NewDFAMatcher(convertedDFA)
+			for _, input := range inputs {
+				assert.Equal(t, explicitMatcher.Match(input), symbolicDFA.Match(input), "input %q", input)
+				assert.Equal(t, explicitMatcher.Match(input), convertedMatcher.Match(input), "input %q", input)
+			}
+		})
+	}
+}
+
+func TestSymbolicUnicodeClasses(t *testing.T) {
+	letters := symbolic.FromRangeTable(unicode.Letter)
+	digits := symbolic.FromRangeTable(unicode.Digit)
+
+	nfa := symbolic.NewNFA()
+	nfa.States = []string{"q0", "q1", "q2"}
+	nfa.StartState = "q0"
+	nfa.AcceptingStates["q2"] = true
+	nfa.Transitions["q0"] = []symbolic.Edge{{Predicate: letters, To: "q1"}}
+	nfa.Transitions["q1"] = []symbolic.Edge{
+		{Predicate: letters, To: "q1"},
+		{Predicate: letters.Union(digits), To: "q0"},
+		{Predicate: digits, To: "q2"},
+	}
+
+	dfa := symbolic.Minimize(symbolic.Determinize(nfa))
+	assert.Len(t, dfa.States, 3)
+	assert.True(t, dfa.Match("abc1"))
+	assert.True(t, dfa.Match("привет٣"))
+	assert.True(t, dfa.Match("x1y2"))
+	assert.False(t, dfa.Match("1"))
+	assert.False(t, dfa.Match("ab"))
+
+	_, err := symbolic.ToDFA(dfa, 256)
+	assert.Error(t, err)
+}
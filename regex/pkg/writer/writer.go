@@ -6,6 +6,8 @@ import (
 	"sort"
 	"strings"
 
+	"dot/pkg/dot"
+	"fsm/pkg/automaton"
 	"regex/pkg/model"
 )
 
@@ -20,20 +22,33 @@ const (
 	transition           = "\t%s -> %s [label = \"%s\"];\n"
 )
 
+type Options struct {
+	MergeParallelEdges bool
+	CompressRanges     bool
+	CompleteWithSink   bool
+	StripSinkStates    bool
+}
+
 type Writer struct {
 	builder strings.Builder
+	options Options
 }
 
 func NewWriter() *Writer {
 	return &Writer{}
 }
 
+func NewWriterWithOptions(options Options) *Writer {
+	return &Writer{options: options}
+}
+
 func (w *Writer) WriteToFile(dfa *model.DFA, filePath string) error {
 	dotString := w.generateDOTString(dfa)
 	return os.WriteFile(filePath, []byte(dotString), 0644)
 }
 
 func (w *Writer) generateDOTString(dfa *model.DFA) string {
+	dfa = automaton.ApplySinkOptions(dfa, w.options.CompleteWithSink, w.options.StripSinkStates)
 	w.builder.Reset()
 	w.writeHeader()
 	w.writeNodes(dfa)
@@ -91,6 +106,10 @@ func (w *Writer) writeTransitions(dfa *model.DFA) {
 
 	for _, from := range sortedStates {
 		transitions := dfa.Transitions[from]
+		if w.options.MergeParallelEdges {
+			w.writeMergedTransitions(from, transitions)
+			continue
+		}
 		sortedSymbols := make([]string, 0, len(transitions))
 		for symbol := range transitions {
 			sortedSymbols = append(sortedSymbols, symbol)
@@ -99,12 +118,19 @@ func (w *Writer) writeTransitions(dfa *model.DFA) {
 
 		for _, symbol := range sortedSymbols {
 			to := transitions[symbol]
-			line := fmt.Sprintf(transition, from, to, symbol)
+			line := fmt.Sprintf(transition, from, to, dot.EscapeLabel(dot.EscapeSymbol(symbol)))
 			w.builder.WriteString(line)
 		}
 	}
 }
 
+func (w *Writer) writeMergedTransitions(from string, transitions map[string]string) {
+	for _, edge := range dot.MergeEdges(dot.GroupByTarget(transitions), w.options.CompressRanges) {
+		line := fmt.Sprintf(transition, from, edge.To, dot.EscapeLabel(edge.Label))
+		w.builder.WriteString(line)
+	}
+}
+
 func (w *Writer) writeFooter() {
 	w.builder.WriteString(digraphFooter)
 }